@@ -0,0 +1,161 @@
+package rank
+
+import (
+	"context"
+	"time"
+)
+
+// WithActivityTracking : record the time of the last write of every member, for LastActive and PruneInactive.
+func WithActivityTracking() Option {
+	return func(lb *Leaderboard) {
+		lb.activity = true
+	}
+}
+
+// WithMemberTTL : every write of a member expires it ttl later, for PruneExpired.
+// members written before the option was set never expire unless ExpireMember is called.
+func WithMemberTTL(ttl time.Duration) Option {
+	return func(lb *Leaderboard) {
+		lb.memberTTL = ttl
+	}
+}
+
+// activityKey : sorted set of member -> unix milliseconds of its last write.
+func (lb *Leaderboard) activityKey() string {
+	return lb.Name + ":active"
+}
+
+// expiryKey : sorted set of member -> unix milliseconds when it expires.
+func (lb *Leaderboard) expiryKey() string {
+	return lb.Name + ":expires"
+}
+
+func unixMilli(t time.Time) float64 {
+	return float64(t.UnixMilli())
+}
+
+// timeOfMilli : time of a score of the activity or expiry sets.
+func timeOfMilli(score float64) time.Time {
+	return time.UnixMilli(int64(score))
+}
+
+// record : record the write time of members and push their expiry back.
+func (lb *Leaderboard) record(ctx context.Context, members ...string) error {
+	if !lb.tracksTimes() || len(members) == 0 {
+		return nil
+	}
+
+	now := lb.now()
+	if lb.activity {
+		entries := make([]Entry, 0, len(members))
+		for _, member := range members {
+			entries = append(entries, Entry{Member: member, Score: unixMilli(now)})
+		}
+		if err := lb.backend.ZAdd(ctx, lb.activityKey(), entries...); err != nil {
+			return err
+		}
+	}
+	if lb.memberTTL > 0 {
+		entries := make([]Entry, 0, len(members))
+		for _, member := range members {
+			entries = append(entries, Entry{Member: member, Score: unixMilli(now.Add(lb.memberTTL))})
+		}
+		if err := lb.backend.ZAdd(ctx, lb.expiryKey(), entries...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LastActive : Retrieve the time of the last write of a member. returns ErrMemberNotFound when none was recorded.
+func (lb *Leaderboard) LastActive(ctx context.Context, member string) (time.Time, error) {
+	score, found, err := lb.backend.ZScore(ctx, lb.activityKey(), member)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !found {
+		return time.Time{}, ErrMemberNotFound
+	}
+	return timeOfMilli(score), nil
+}
+
+// ExpireMember : Expire a member ttl from now, until its next write under WithMemberTTL.
+// the member is removed by the next PruneExpired after that. returns ErrMemberNotFound for a non-existent member.
+func (lb *Leaderboard) ExpireMember(ctx context.Context, member string, ttl time.Duration) error {
+	if ok, err := lb.CheckMember(ctx, member); err != nil {
+		return err
+	} else if !ok {
+		return ErrMemberNotFound
+	}
+	return lb.backend.ZAdd(ctx, lb.expiryKey(), Entry{Member: member, Score: unixMilli(lb.now().Add(ttl))})
+}
+
+// PersistMember : Remove the expiry of a member set by ExpireMember or WithMemberTTL.
+func (lb *Leaderboard) PersistMember(ctx context.Context, member string) error {
+	_, err := lb.backend.ZRem(ctx, lb.expiryKey(), member)
+	return err
+}
+
+// MemberTTL : Retrieve the time left before a member expires. returns ErrMemberNotFound when it has no expiry.
+func (lb *Leaderboard) MemberTTL(ctx context.Context, member string) (time.Duration, error) {
+	score, found, err := lb.backend.ZScore(ctx, lb.expiryKey(), member)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, ErrMemberNotFound
+	}
+	return timeOfMilli(score).Sub(lb.now()), nil
+}
+
+// PruneExpired : Remove the members whose expiry has passed. returns the number of members removed.
+func (lb *Leaderboard) PruneExpired(ctx context.Context) (int, error) {
+	return lb.prune(ctx, lb.expiryKey(), lb.now())
+}
+
+// PruneInactive : Remove the members not written for longer than idle. returns the number of members removed.
+// only the writes made under WithActivityTracking are known. members never written under it are kept.
+func (lb *Leaderboard) PruneInactive(ctx context.Context, idle time.Duration) (int, error) {
+	return lb.prune(ctx, lb.activityKey(), lb.now().Add(-idle))
+}
+
+// prune : remove the members scored up to t in the time set key, from the leaderboard and the time sets.
+// the members are picked and removed in one atomic step, so a member written meanwhile is kept.
+func (lb *Leaderboard) prune(ctx context.Context, key string, t time.Time) (int, error) {
+	max := scoreBound(unixMilli(t), false)
+
+	var before snapshot
+	if lb.tracksChanges() {
+		// the standings reported by the events of the members due now.
+		due, err := lb.backend.ZRangeByScore(ctx, key, "-inf", max)
+		if err != nil {
+			return 0, err
+		}
+		members := make([]string, 0, len(due))
+		for _, entry := range due {
+			members = append(members, entry.Member)
+		}
+		if before, err = lb.snapshot(ctx, members...); err != nil {
+			return 0, err
+		}
+	}
+
+	// members may have left the leaderboard without leaving the time sets. only the others are reported.
+	removed, err := lb.backend.ZRemDue(ctx, key, max, lb.Name, lb.activityKey(), lb.expiryKey())
+	if err != nil || len(removed) == 0 {
+		return 0, err
+	}
+	if err := lb.removed(ctx, before, removed...); err != nil {
+		return 0, err
+	}
+	return len(removed), nil
+}
+
+// forgetTimes : drop members from the activity and expiry sets.
+func (lb *Leaderboard) forgetTimes(ctx context.Context, members ...string) error {
+	if _, err := lb.backend.ZRem(ctx, lb.activityKey(), members...); err != nil {
+		return err
+	}
+	_, err := lb.backend.ZRem(ctx, lb.expiryKey(), members...)
+	return err
+}
@@ -0,0 +1,96 @@
+package rank
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func testActivity(t *testing.T, b Backend) {
+	ctx := context.Background()
+	lb := NewLeaderboardWithBackend(b, lbName, WithActivityTracking(), WithMemberTTL(time.Hour))
+	defer lb.Delete(ctx)
+	now := time.UnixMilli(1700000000000)
+	lb.now = func() time.Time { return now }
+
+	lb.RankMember(ctx, "member_1", 10)
+	lb.RankMember(ctx, "member_2", 20)
+	// ranked without tracking. never pruned.
+	NewLeaderboardWithBackend(b, lbName).RankMember(ctx, "member_4", 40)
+
+	now = now.Add(30 * time.Minute)
+	lb.RankMember(ctx, "member_3", 30)
+	lb.ChangeScoreFor(ctx, "member_2", 5)
+
+	if active, err := lb.LastActive(ctx, "member_1"); err != nil || !active.Equal(now.Add(-30*time.Minute)) {
+		t.Error("Leaderboard LastActive Err!", active, err)
+	}
+	if active, err := lb.LastActive(ctx, "member_2"); err != nil || !active.Equal(now) {
+		t.Error("Leaderboard LastActive Err!", active, err)
+	}
+	if _, err := lb.LastActive(ctx, "member_4"); !errors.Is(err, ErrMemberNotFound) {
+		t.Error("Leaderboard LastActive Err!", err)
+	}
+
+	if removed, err := lb.PruneInactive(ctx, 20*time.Minute); err != nil || removed != 1 {
+		t.Error("Leaderboard PruneInactive Err!", removed, err)
+	}
+	if ok, _ := lb.CheckMember(ctx, "member_1"); ok {
+		t.Error("Leaderboard PruneInactive Err! member_1 kept")
+	}
+	if _, err := lb.LastActive(ctx, "member_1"); !errors.Is(err, ErrMemberNotFound) {
+		t.Error("Leaderboard PruneInactive LastActive Err!", err)
+	}
+	if total, _ := lb.TotalMembers(ctx); total != 3 {
+		t.Error("Leaderboard PruneInactive TotalMembers Err!", total)
+	}
+
+	if ttl, err := lb.MemberTTL(ctx, "member_3"); err != nil || ttl != time.Hour {
+		t.Error("Leaderboard MemberTTL Err!", ttl, err)
+	}
+	if err := lb.ExpireMember(ctx, "member_3", time.Minute); err != nil {
+		t.Error("Leaderboard ExpireMember Err!", err)
+	}
+	if err := lb.ExpireMember(ctx, "jones", time.Minute); !errors.Is(err, ErrMemberNotFound) {
+		t.Error("Leaderboard ExpireMember Err!", err)
+	}
+	if removed, err := lb.PruneExpired(ctx); err != nil || removed != 0 {
+		t.Error("Leaderboard PruneExpired Err!", removed, err)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if removed, err := lb.PruneExpired(ctx); err != nil || removed != 1 {
+		t.Error("Leaderboard PruneExpired Err!", removed, err)
+	}
+	if ok, _ := lb.CheckMember(ctx, "member_3"); ok {
+		t.Error("Leaderboard PruneExpired Err! member_3 kept")
+	}
+
+	lb.PersistMember(ctx, "member_2")
+	if _, err := lb.MemberTTL(ctx, "member_2"); !errors.Is(err, ErrMemberNotFound) {
+		t.Error("Leaderboard PersistMember Err!", err)
+	}
+	now = now.Add(2 * time.Hour)
+	if removed, err := lb.PruneExpired(ctx); err != nil || removed != 0 {
+		t.Error("Leaderboard PruneExpired Persist Err!", removed, err)
+	}
+
+	// removals drop the member from the time sets.
+	lb.RemoveMember(ctx, "member_2")
+	if _, err := lb.LastActive(ctx, "member_2"); !errors.Is(err, ErrMemberNotFound) {
+		t.Error("Leaderboard RemoveMember LastActive Err!", err)
+	}
+	if removed, err := lb.PruneInactive(ctx, time.Minute); err != nil || removed != 0 {
+		t.Error("Leaderboard PruneInactive Err!", removed, err)
+	}
+	if ok, _ := lb.CheckMember(ctx, "member_4"); !ok {
+		t.Error("Leaderboard PruneInactive Err! member_4 removed")
+	}
+}
+
+func TestActivity(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	testActivity(t, backend)
+	testActivity(t, NewMemoryBackend())
+}
@@ -0,0 +1,127 @@
+package rank
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrInvalidWeights : the Weights of a Combine are not one per source.
+var ErrInvalidWeights = errors.New("rank: combine needs one weight per source")
+
+// Aggregate : how the scores of a member on several leaderboards are combined.
+type Aggregate string
+
+const (
+	// AggregateSum : add the scores. e.g. the total score across all levels.
+	AggregateSum Aggregate = "SUM"
+	// AggregateMin : keep the lowest score.
+	AggregateMin Aggregate = "MIN"
+	// AggregateMax : keep the highest score.
+	AggregateMax Aggregate = "MAX"
+)
+
+// apply : combine two weighted scores.
+func (a Aggregate) apply(x float64, y float64) float64 {
+	switch a {
+	case AggregateMin:
+		if y < x {
+			return y
+		}
+		return x
+	case AggregateMax:
+		if y > x {
+			return y
+		}
+		return x
+	}
+	return x + y
+}
+
+// Combine : how the source leaderboards of UnionOf and InterOf are combined.
+type Combine struct {
+	// Aggregate : default AggregateSum.
+	Aggregate Aggregate
+	// Weights : multiplier of the scores of each source, one per source. nil weighs every source 1.
+	Weights []float64
+	// TTL : delete the result after TTL, for ephemeral views. 0 keeps it.
+	TTL time.Duration
+}
+
+// UnionOf : Replace the leaderboard with the members of any of the sources and their combined scores.
+// sources must be stored in the same backend as lb. returns the number of members.
+// decayed scores are combined as they are now, see combineArgs.
+func (lb *Leaderboard) UnionOf(ctx context.Context, sources []*Leaderboard, combine Combine) (int, error) {
+	keys, weights, err := lb.combineArgs(sources, combine)
+	if err != nil {
+		return 0, err
+	}
+	count, err := lb.backend.ZUnionStore(ctx, lb.Name, keys, weights, combine.Aggregate)
+	if err != nil {
+		return 0, err
+	}
+	return count, lb.afterCombine(ctx, combine)
+}
+
+// InterOf : Replace the leaderboard with the members found in all the sources and their combined scores.
+// sources must be stored in the same backend as lb. returns the number of members.
+// decayed scores are combined as they are now, see combineArgs.
+func (lb *Leaderboard) InterOf(ctx context.Context, sources []*Leaderboard, combine Combine) (int, error) {
+	keys, weights, err := lb.combineArgs(sources, combine)
+	if err != nil {
+		return 0, err
+	}
+	count, err := lb.backend.ZInterStore(ctx, lb.Name, keys, weights, combine.Aggregate)
+	if err != nil {
+		return 0, err
+	}
+	return count, lb.afterCombine(ctx, combine)
+}
+
+// combineArgs : keys and weights of sources. weights also convert the precision and the decay of each source
+// to the ones of lb. decayed scores are converted as they are now: sources and lb with the same decay and epoch
+// keep decaying together, others give a snapshot that decays with lb from now on.
+// returns ErrInvalidWeights when combine.Weights is not one per source.
+func (lb *Leaderboard) combineArgs(sources []*Leaderboard, combine Combine) ([]string, []float64, error) {
+	if combine.Weights != nil && len(combine.Weights) != len(sources) {
+		return nil, nil, ErrInvalidWeights
+	}
+	if err := lb.decayErr(); err != nil {
+		return nil, nil, err
+	}
+
+	keys := make([]string, 0, len(sources))
+	weights := make([]float64, 0, len(sources))
+	for i, source := range sources {
+		if err := source.decayErr(); err != nil {
+			return nil, nil, err
+		}
+		weight := 1.0
+		if combine.Weights != nil {
+			weight = combine.Weights[i]
+		}
+		weight *= lb.scaleFactor() / source.scaleFactor()
+		if source.decay != nil {
+			weight /= source.decay.weight(source.now())
+		}
+		if lb.decay != nil {
+			weight *= lb.decay.weight(lb.now())
+		}
+		keys = append(keys, source.Name)
+		weights = append(weights, weight)
+	}
+	return keys, weights, nil
+}
+
+// afterCombine : drop the submission times and data of the replaced members and apply the TTL.
+func (lb *Leaderboard) afterCombine(ctx context.Context, combine Combine) error {
+	for _, key := range []string{lb.timestampKey(), lb.memberDataKey()} {
+		if err := lb.backend.Del(ctx, key); err != nil {
+			return err
+		}
+	}
+	if combine.TTL > 0 {
+		return lb.ExpireAt(ctx, time.Now().Add(combine.TTL))
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package rank
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func testAggregate(t *testing.T, b Backend) {
+	ctx := context.Background()
+	level1 := NewLeaderboardWithBackend(b, lbName+"_level1")
+	level2 := NewLeaderboardWithBackend(b, lbName+"_level2", WithPrecision(2))
+	total := NewLeaderboardWithBackend(b, lbName+"_total")
+	defer level1.Delete(ctx)
+	defer level2.Delete(ctx)
+	defer total.Delete(ctx)
+
+	level1.RankMember(ctx, "member_1", 10)
+	level1.RankMember(ctx, "member_2", 30)
+	level2.RankMember(ctx, "member_1", 25.5)
+	level2.RankMember(ctx, "member_3", 5)
+
+	if count, err := total.UnionOf(ctx, []*Leaderboard{level1, level2}, Combine{}); err != nil || count != 3 {
+		t.Error("Leaderboard UnionOf Err!", count, err)
+	}
+	if score, _ := total.ScoreFor(ctx, "member_1"); score != 35.5 {
+		t.Error("Leaderboard UnionOf Err!", score)
+	}
+	if members, _ := total.Members(ctx, 1, 0); len(members) != 3 || members[0].Member != "member_1" || members[2].Member != "member_3" {
+		t.Error("Leaderboard Members Err!", members)
+	}
+
+	// weighted max replaces the previous result.
+	total.UnionOf(ctx, []*Leaderboard{level1, level2}, Combine{Aggregate: AggregateMax, Weights: []float64{2, 1}})
+	if score, _ := total.ScoreFor(ctx, "member_1"); score != 25.5 {
+		t.Error("Leaderboard UnionOf Err!", score)
+	}
+	if rank, _ := total.RankFor(ctx, "member_2"); rank != 1 {
+		t.Error("Leaderboard RankFor Err!", rank)
+	}
+
+	if count, _ := total.InterOf(ctx, []*Leaderboard{level1, level2}, Combine{Aggregate: AggregateMin}); count != 1 {
+		t.Error("Leaderboard InterOf Err!", count)
+	}
+	if members, _ := total.AllMembers(ctx); len(members) != 1 || members[0].Member != "member_1" || members[0].GetScore() != 10 {
+		t.Error("Leaderboard InterOf Err!", members)
+	}
+
+	if _, err := total.UnionOf(ctx, []*Leaderboard{level1, level2}, Combine{Weights: []float64{2}}); !errors.Is(err, ErrInvalidWeights) {
+		t.Error("Leaderboard UnionOf Weights Err!", err)
+	}
+
+	// decayed scores are combined as they are now.
+	epoch := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	trending := NewLeaderboardWithBackend(b, lbName+"_trending", WithExponentialDecay(time.Hour, epoch))
+	defer trending.Delete(ctx)
+	now := epoch
+	trending.now = func() time.Time { return now }
+	trending.RankMember(ctx, "member_1", 100)
+	now = now.Add(time.Hour)
+	total.UnionOf(ctx, []*Leaderboard{level1, trending}, Combine{})
+	if score, _ := total.ScoreFor(ctx, "member_1"); score != 60 {
+		t.Error("Leaderboard UnionOf Decay Err!", score)
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	testAggregate(t, backend)
+	testAggregate(t, NewMemoryBackend())
+}
+
+func TestAggregateTTL(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	ctx := context.Background()
+	b := NewMemoryBackend()
+	level1 := NewLeaderboardWithBackend(b, lbName+"_level1")
+	total := NewLeaderboardWithBackend(b, lbName+"_total")
+	level1.RankMember(ctx, "member_1", 10)
+
+	// ephemeral views expire.
+	total.UnionOf(ctx, []*Leaderboard{level1}, Combine{TTL: time.Millisecond})
+	time.Sleep(10 * time.Millisecond)
+	if count, _ := total.TotalMembers(ctx); count != 0 {
+		t.Error("Leaderboard UnionOf TTL Err!", count)
+	}
+}
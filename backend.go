@@ -0,0 +1,192 @@
+package rank
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Entry : member and score pair stored in a backend.
+type Entry struct {
+	Member string
+	Score  float64
+}
+
+// ScoreUpdate : stored score of a member after ZUpdate.
+type ScoreUpdate struct {
+	Member  string
+	Score   float64
+	Changed bool
+	// Higher : number of members with a higher score.
+	Higher int
+	// Lower : number of members with a lower score.
+	Lower int
+}
+
+// AddResult : reply of one entry of ZAddEach.
+type AddResult struct {
+	// Added : the member was new. false when its score was updated.
+	Added bool
+	// Changed : the member was new or got a different score.
+	Changed bool
+	Err     error
+}
+
+// ScoreRange : min and max bounds of a score range.
+type ScoreRange struct {
+	Min string
+	Max string
+}
+
+// StreamMessage : message of a stream read by XRead.
+type StreamMessage struct {
+	ID      string
+	Message string
+}
+
+// Backend : sorted set storage used by Leaderboard.
+// semantics follow the redis sorted set commands of the same name.
+// score bounds are redis range strings: "10", "(10" (exclusive), "-inf" and "+inf".
+type Backend interface {
+	ZAdd(ctx context.Context, key string, entries ...Entry) error
+	// ZAddEach : ZAdd every entry with its own reply, chunkSize entries per round trip. chunkSize 0 sends all at once.
+	// with atomic the entries are applied in one MULTI/EXEC transaction.
+	// err is set when the backend failed. results then hold the error for the entries it did not apply.
+	ZAddEach(ctx context.Context, key string, entries []Entry, chunkSize int, atomic bool) (results []AddResult, err error)
+	ZIncrBy(ctx context.Context, key string, member string, delta float64) (float64, error)
+	// ZUpdate : apply entries with policy and count the members above each result, atomically.
+	ZUpdate(ctx context.Context, key string, policy UpdatePolicy, entries ...Entry) ([]ScoreUpdate, error)
+	ZRem(ctx context.Context, key string, members ...string) (int, error)
+	// ZScore : score of member. found is false for a non-existent member.
+	ZScore(ctx context.Context, key string, member string) (score float64, found bool, err error)
+	// ZMScore : ZScore of every member in one round trip.
+	ZMScore(ctx context.Context, key string, members ...string) (scores []float64, found []bool, err error)
+	ZCard(ctx context.Context, key string) (int, error)
+	ZCount(ctx context.Context, key string, min string, max string) (int, error)
+	// ZCountDistinct : number of distinct scores in the score range.
+	ZCountDistinct(ctx context.Context, key string, min string, max string) (int, error)
+	// ZMCount : ZCount of every range in one round trip.
+	ZMCount(ctx context.Context, key string, ranges ...ScoreRange) ([]int, error)
+	// ZMCountDistinct : ZCountDistinct of every range in one round trip.
+	ZMCountDistinct(ctx context.Context, key string, ranges ...ScoreRange) ([]int, error)
+	// ZRank : zero based position of member ordered from the lowest score.
+	ZRank(ctx context.Context, key string, member string) (rank int, found bool, err error)
+	// ZRevRank : zero based position of member ordered from the highest score.
+	ZRevRank(ctx context.Context, key string, member string) (rank int, found bool, err error)
+	// ZRankOf : zero based position a member holding score has or would have, ordered from the lowest score or from
+	// the highest when reverse. unlike ZRank, member does not need to be in key.
+	ZRankOf(ctx context.Context, key string, score float64, member string, reverse bool) (int, error)
+	// ZMRank : ZRank of every member in one round trip.
+	ZMRank(ctx context.Context, key string, members ...string) (ranks []int, found []bool, err error)
+	// ZMRevRank : ZRevRank of every member in one round trip.
+	ZMRevRank(ctx context.Context, key string, members ...string) (ranks []int, found []bool, err error)
+	ZRange(ctx context.Context, key string, start int, stop int) ([]Entry, error)
+	ZRevRange(ctx context.Context, key string, start int, stop int) ([]Entry, error)
+	ZRangeByScore(ctx context.Context, key string, min string, max string) ([]Entry, error)
+	ZRevRangeByScore(ctx context.Context, key string, max string, min string) ([]Entry, error)
+	// ZMRangeByScore : ZRangeByScore of every range in one round trip.
+	ZMRangeByScore(ctx context.Context, key string, ranges ...ScoreRange) ([][]Entry, error)
+	// ZRangeByScoreLimit : ZRangeByScore skipping offset entries and returning up to count. count < 0 returns the rest.
+	ZRangeByScoreLimit(ctx context.Context, key string, min string, max string, offset int, count int) ([]Entry, error)
+	// ZRevRangeByScoreLimit : ZRevRangeByScore skipping offset entries and returning up to count. count < 0 returns the rest.
+	ZRevRangeByScoreLimit(ctx context.Context, key string, max string, min string, offset int, count int) ([]Entry, error)
+	ZRemRangeByScore(ctx context.Context, key string, min string, max string) (int, error)
+	// ZRemDue : take the members of key scored up to max out of key, target and others, atomically.
+	// returns the members that were in target.
+	ZRemDue(ctx context.Context, key string, max string, target string, others ...string) ([]string, error)
+	// ZScaleExp2 : multiply every score of key by 2^exp, atomically. 2^exp itself may be out of the float64 range.
+	ZScaleExp2(ctx context.Context, key string, exp float64) error
+	ZRemRangeByRank(ctx context.Context, key string, start int, stop int) (int, error)
+	// ZUnionStore : store the union of the sets at keys in dest, replacing it. nil weights are all 1.
+	ZUnionStore(ctx context.Context, dest string, keys []string, weights []float64, aggregate Aggregate) (int, error)
+	// ZInterStore : store the intersection of the sets at keys in dest, replacing it. nil weights are all 1.
+	ZInterStore(ctx context.Context, dest string, keys []string, weights []float64, aggregate Aggregate) (int, error)
+	// ZInter : entries of key whose member is in the plain set setKey, in no particular order.
+	// like ZINTER key setKey WEIGHTS 1 0 WITHSCORES.
+	ZInter(ctx context.Context, key string, setKey string) ([]Entry, error)
+	// ZSumStore : set the score of member in dest to the sum of the scores at the zero based indexes
+	// start..stop of src, from the highest score when reverse. member leaves dest when the range is empty.
+	ZSumStore(ctx context.Context, dest string, member string, src string, start int, stop int, reverse bool) (float64, error)
+	// ZTopStore : replace the plain set dest with the members of src ranked n or better under mode, from the
+	// highest score when reverse, atomically. ties of RankEarliestFirst are ordered by the hash of submission
+	// times at timestamps. returns the members that entered and left dest.
+	ZTopStore(ctx context.Context, dest string, src string, n int, reverse bool, mode RankingMode, timestamps string) (entered []string, left []string, err error)
+	HSet(ctx context.Context, key string, values map[string]string) error
+	// HMGet : values of the fields. missing fields are left out of the map.
+	HMGet(ctx context.Context, key string, fields ...string) (map[string]string, error)
+	// HGetAll : every field and value of the hash.
+	HGetAll(ctx context.Context, key string) (map[string]string, error)
+	HDel(ctx context.Context, key string, fields ...string) error
+	SAdd(ctx context.Context, key string, members ...string) error
+	SRem(ctx context.Context, key string, members ...string) error
+	SMembers(ctx context.Context, key string) ([]string, error)
+	Del(ctx context.Context, key string) error
+	// RenameNX : rename every existing key of renames to its new name, atomically.
+	// nothing is renamed and ok is false when one of the new names exists.
+	RenameNX(ctx context.Context, renames map[string]string) (ok bool, err error)
+	// Publish : send messages to the subscribers of channel.
+	Publish(ctx context.Context, channel string, messages ...string) error
+	// Subscribe : receive the messages of channel until ctx is done. the returned channel is closed then.
+	Subscribe(ctx context.Context, channel string) (<-chan string, error)
+	// XAdd : append messages to stream, trimmed to about maxLen messages. maxLen 0 keeps all.
+	XAdd(ctx context.Context, stream string, maxLen int, messages ...string) error
+	// XRead : up to count messages of stream after the ID lastID. "$" reads only new messages.
+	// waits up to block for a message when there is none. block 0 returns at once.
+	XRead(ctx context.Context, stream string, lastID string, count int, block time.Duration) ([]StreamMessage, error)
+	// ExpireAt : delete key at t. a t in the past deletes it now.
+	ExpireAt(ctx context.Context, key string, t time.Time) error
+	Close() error
+}
+
+// scoreBound : format score as a range bound. exclusive bounds get the "(" prefix.
+func scoreBound(score float64, exclusive bool) string {
+	s := strconv.FormatFloat(score, 'f', -1, 64)
+	if math.IsInf(score, 1) {
+		s = "+inf"
+	} else if math.IsInf(score, -1) {
+		s = "-inf"
+	}
+	if exclusive {
+		return "(" + s
+	}
+	return s
+}
+
+// parseScoreBound : parse a range bound made by scoreBound or written by hand.
+func parseScoreBound(bound string) (score float64, exclusive bool, err error) {
+	if strings.HasPrefix(bound, "(") {
+		exclusive = true
+		bound = bound[1:]
+	}
+	switch strings.ToLower(bound) {
+	case "+inf", "inf":
+		return math.Inf(1), exclusive, nil
+	case "-inf":
+		return math.Inf(-1), exclusive, nil
+	}
+	score, err = strconv.ParseFloat(bound, 64)
+	return score, exclusive, err
+}
+
+// normalizeIndexRange : clamp redis style start/stop indexes (negative counts from the end) to [0,length).
+// ok is false when the range is empty.
+func normalizeIndexRange(start int, stop int, length int) (int, int, bool) {
+	if start < 0 {
+		start += length
+	}
+	if stop < 0 {
+		stop += length
+	}
+	if start < 0 {
+		start = 0
+	}
+	if start > stop || start >= length {
+		return 0, 0, false
+	}
+	if stop >= length {
+		stop = length - 1
+	}
+	return start, stop, true
+}
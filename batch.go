@@ -0,0 +1,159 @@
+package rank
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// DEFAULT_CHUNKSIZE : 1000
+const DEFAULT_CHUNKSIZE int = 1000
+
+// BatchOptions : how RankMembersBatch writes a batch.
+type BatchOptions struct {
+	// ChunkSize : members sent per round trip. 0 is DEFAULT_CHUNKSIZE.
+	ChunkSize int
+	// Transaction : apply the whole batch in one MULTI/EXEC transaction, so readers never see half of it
+	// and a member with an invalid score fails the whole batch.
+	Transaction bool
+}
+
+// MemberResult : outcome of one member of RankMembersBatch.
+type MemberResult struct {
+	Member string
+	// Added : the member was new. false when its score was updated.
+	Added bool
+	// Err : why the member was not ranked. nil when it was.
+	Err error
+}
+
+// BatchReport : outcome of every member of RankMembersBatch, in the order of the batch.
+type BatchReport struct {
+	Results []*MemberResult
+	Added   int
+	Updated int
+	Failed  int
+}
+
+// Err : nil when every member was ranked, else a BatchError.
+func (r *BatchReport) Err() error {
+	if r.Failed == 0 {
+		return nil
+	}
+	return &BatchError{Report: r}
+}
+
+// BatchError : error of a batch where some members were not ranked.
+type BatchError struct {
+	Report *BatchReport
+}
+
+func (e *BatchError) Error() string {
+	for _, result := range e.Report.Results {
+		if result.Err != nil {
+			return fmt.Sprintf("rank: %d of %d members not ranked, first %s: %v", e.Report.Failed, len(e.Report.Results), result.Member, result.Err)
+		}
+	}
+	return "rank: batch failed"
+}
+
+// Unwrap : error of the first failed member.
+func (e *BatchError) Unwrap() error {
+	for _, result := range e.Report.Results {
+		if result.Err != nil {
+			return result.Err
+		}
+	}
+	return nil
+}
+
+// RankMembersBatch : Rank an array of members, reading the reply of every member.
+// large batches are sent in chunks. the report tells which members were ranked, and err is set when
+// some were not. the members ranked before a failure stay ranked unless options.Transaction.
+func (lb *Leaderboard) RankMembersBatch(ctx context.Context, membersAndScores []*RankScore, options BatchOptions) (*BatchReport, error) {
+	report := &BatchReport{Results: make([]*MemberResult, 0, len(membersAndScores))}
+	if options.ChunkSize < 1 {
+		options.ChunkSize = DEFAULT_CHUNKSIZE
+	}
+
+	entries := make([]Entry, 0, len(membersAndScores))
+	members := make([]string, 0, len(membersAndScores))
+	invalid := make(map[int]error)
+	for i, memberScore := range membersAndScores {
+		report.Results = append(report.Results, &MemberResult{Member: memberScore.Member})
+		if math.IsNaN(memberScore.score) {
+			invalid[i] = fmt.Errorf("rank: invalid score %v", memberScore.score)
+			continue
+		}
+		value, err := lb.stored(memberScore.score)
+		if err != nil {
+			invalid[i] = err
+			continue
+		}
+		entries = append(entries, Entry{Member: memberScore.Member, Score: value})
+		members = append(members, memberScore.Member)
+	}
+
+	fail := func(err error) (*BatchReport, error) {
+		for _, result := range report.Results {
+			if result.Err == nil {
+				result.Err = err
+			}
+		}
+		report.Added, report.Updated, report.Failed = 0, 0, len(report.Results)
+		return report, report.Err()
+	}
+
+	for i, err := range invalid {
+		report.Results[i].Err = err
+	}
+	if len(invalid) > 0 && options.Transaction {
+		return fail(fmt.Errorf("rank: transaction not run, %d invalid scores", len(invalid)))
+	}
+
+	before, err := lb.snapshot(ctx, members...)
+	if err != nil {
+		return fail(err)
+	}
+	results, err := lb.backend.ZAddEach(ctx, lb.Name, entries, options.ChunkSize, options.Transaction)
+	if results == nil {
+		return fail(err)
+	}
+
+	ranked := make([]string, 0, len(members))
+	var changed []string
+	for i, j := 0, 0; i < len(report.Results); i++ {
+		result := report.Results[i]
+		if _, ok := invalid[i]; ok {
+			report.Failed++
+			continue
+		}
+		result.Added, result.Err = results[j].Added, results[j].Err
+		if result.Err == nil && results[j].Changed {
+			changed = append(changed, result.Member)
+		}
+		j++
+
+		switch {
+		case result.Err != nil:
+			report.Failed++
+		case result.Added:
+			report.Added++
+		default:
+			report.Updated++
+		}
+		if result.Err == nil {
+			ranked = append(ranked, result.Member)
+		}
+	}
+
+	if len(ranked) > 0 {
+		if err := lb.touch(ctx, changed...); err != nil {
+			return report, err
+		}
+		if err := lb.written(ctx, before, ranked...); err != nil {
+			return report, err
+		}
+	}
+	return report, report.Err()
+}
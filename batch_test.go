@@ -0,0 +1,74 @@
+package rank
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+)
+
+func testRankMembersBatch(t *testing.T, b Backend) {
+	ctx := context.Background()
+	lb := NewLeaderboardWithBackend(b, lbName)
+	defer lb.Delete(ctx)
+
+	lb.RankMember(ctx, "member_1", 1)
+	report, err := lb.RankMembersBatch(ctx, []*RankScore{
+		NewRankScore("member_1", 10),
+		NewRankScore("member_2", 20),
+		NewRankScore("member_3", math.NaN()),
+		NewRankScore("member_4", 40),
+		NewRankScore("member_5", 50),
+	}, BatchOptions{ChunkSize: 2})
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || report.Added != 3 || report.Updated != 1 || report.Failed != 1 {
+		t.Fatal("Leaderboard RankMembersBatch Err!", report, err)
+	}
+	if r := report.Results[2]; r.Member != "member_3" || r.Err == nil {
+		t.Error("Leaderboard RankMembersBatch Err!", r)
+	}
+	if r := report.Results[0]; r.Added || r.Err != nil {
+		t.Error("Leaderboard RankMembersBatch Err!", r)
+	}
+	if total, _ := lb.TotalMembers(ctx); total != 4 {
+		t.Error("Leaderboard RankMembersBatch Err!", total)
+	}
+	if score, _ := lb.ScoreFor(ctx, "member_1"); score != 10 {
+		t.Error("Leaderboard RankMembersBatch Err!", score)
+	}
+
+	// an invalid score fails the whole transaction.
+	report, err = lb.RankMembersBatch(ctx, []*RankScore{
+		NewRankScore("member_6", 60),
+		NewRankScore("member_7", math.NaN()),
+	}, BatchOptions{Transaction: true})
+	if err == nil || report.Failed != 2 {
+		t.Error("Leaderboard RankMembersBatch Transaction Err!", report, err)
+	}
+	if ok, _ := lb.CheckMember(ctx, "member_6"); ok {
+		t.Error("Leaderboard RankMembersBatch Transaction Err!")
+	}
+
+	report, err = lb.RankMembersBatch(ctx, []*RankScore{
+		NewRankScore("member_6", 60),
+		NewRankScore("member_7", 70),
+		NewRankScore("member_1", 5),
+	}, BatchOptions{ChunkSize: 2, Transaction: true})
+	if err != nil || report.Added != 2 || report.Updated != 1 {
+		t.Error("Leaderboard RankMembersBatch Transaction Err!", report, err)
+	}
+	if rank, _ := lb.RankFor(ctx, "member_7"); rank != 1 {
+		t.Error("Leaderboard RankMembersBatch Transaction Err!", rank)
+	}
+
+	// the connection stays usable after the batches.
+	if total, err := lb.TotalMembers(ctx); err != nil || total != 6 {
+		t.Error("Leaderboard RankMembersBatch TotalMembers Err!", total, err)
+	}
+}
+
+func TestRankMembersBatch(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	testRankMembersBatch(t, backend)
+	testRankMembersBatch(t, NewMemoryBackend())
+}
@@ -0,0 +1,46 @@
+// Package cmdflags : command line flags of the leaderboard options, shared by the leaderboard commands.
+package cmdflags
+
+import (
+	"flag"
+
+	rank "github.com/jaksal/leaderboard"
+)
+
+// Leaderboard : leaderboard options set by the flags.
+type Leaderboard struct {
+	Ranking    string
+	Ascending  bool
+	Precision  int
+	MemberData bool
+}
+
+// LeaderboardFlags : define the flags of the leaderboard options on the default flag set.
+// memberData is the usage of -member-data, which differs between the commands.
+func LeaderboardFlags(memberData string) *Leaderboard {
+	f := &Leaderboard{}
+	flag.StringVar(&f.Ranking, "ranking", "standard", "ranking mode: standard, dense, ordinal or earliest")
+	flag.BoolVar(&f.Ascending, "ascending", false, "rank the lowest score first")
+	flag.IntVar(&f.Precision, "precision", -1, "decimal places kept in scores. -1 keeps them as given")
+	flag.BoolVar(&f.MemberData, "member-data", false, memberData)
+	return f
+}
+
+// Options : leaderboard options of the parsed flags. an error for an unknown ranking mode.
+func (f *Leaderboard) Options() ([]rank.Option, error) {
+	mode, err := rank.ParseRankingMode(f.Ranking)
+	if err != nil {
+		return nil, err
+	}
+	options := []rank.Option{rank.WithRankingMode(mode)}
+	if f.Ascending {
+		options = append(options, rank.WithSortOrder(rank.Ascending))
+	}
+	if f.Precision >= 0 {
+		options = append(options, rank.WithPrecision(f.Precision))
+	}
+	if f.MemberData {
+		options = append(options, rank.WithMemberData())
+	}
+	return options, nil
+}
@@ -0,0 +1,47 @@
+// Command leaderboard-grpc serves the leaderboards of a redis server over grpc. see package rankgrpc.
+//
+//	leaderboard-grpc -listen :9090 -redis 127.0.0.1:6379 -ranking dense
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+
+	rank "github.com/jaksal/leaderboard"
+	"github.com/jaksal/leaderboard/cmd/internal/cmdflags"
+	"github.com/jaksal/leaderboard/rankgrpc"
+	"github.com/jaksal/leaderboard/rankpb"
+
+	"google.golang.org/grpc"
+)
+
+func main() {
+	listen := flag.String("listen", ":9090", "address to serve on")
+	redisAddr := flag.String("redis", "127.0.0.1:6379", "redis server address. empty keeps the leaderboards in memory")
+	leaderboard := cmdflags.LeaderboardFlags("return the member data of the leaderboards")
+	flag.Parse()
+
+	options, err := leaderboard.Options()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var backend rank.Backend
+	if *redisAddr == "" {
+		backend = rank.NewMemoryBackend()
+	} else {
+		backend = rank.NewRedisBackend(rank.NewPool(*redisAddr))
+	}
+	defer backend.Close()
+
+	lis, err := net.Listen("tcp", *listen)
+	if err != nil {
+		log.Fatal(err)
+	}
+	server := grpc.NewServer()
+	rankpb.RegisterLeaderboardServer(server, rankgrpc.NewServer(backend, options...))
+
+	log.Printf("serving leaderboards on %s", *listen)
+	log.Fatal(server.Serve(lis))
+}
@@ -0,0 +1,46 @@
+// Command leaderboard-server serves the leaderboards of a redis server as JSON endpoints. see package rankhttp.
+//
+//	leaderboard-server -listen :8080 -redis 127.0.0.1:6379 -ranking dense
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	rank "github.com/jaksal/leaderboard"
+	"github.com/jaksal/leaderboard/cmd/internal/cmdflags"
+	"github.com/jaksal/leaderboard/rankhttp"
+)
+
+func main() {
+	listen := flag.String("listen", ":8080", "address to serve on")
+	redisAddr := flag.String("redis", "127.0.0.1:6379", "redis server address. empty keeps the leaderboards in memory")
+	leaderboard := cmdflags.LeaderboardFlags("return the member data of the leaderboards")
+	flag.Parse()
+
+	options, err := leaderboard.Options()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var backend rank.Backend
+	if *redisAddr == "" {
+		backend = rank.NewMemoryBackend()
+	} else {
+		backend = rank.NewRedisBackend(rank.NewPool(*redisAddr))
+	}
+	defer backend.Close()
+
+	server := &http.Server{
+		Addr:              *listen,
+		Handler:           rankhttp.NewHandler(backend, options...),
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       15 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       2 * time.Minute,
+	}
+	log.Printf("serving leaderboards on %s", *listen)
+	log.Fatal(server.ListenAndServe())
+}
@@ -0,0 +1,400 @@
+// Command leaderboard inspects and operates the leaderboards of a redis server.
+//
+//	leaderboard [flags] <command> <leaderboard> [args]
+//
+//	top <leaderboard> [n]                      members ranked 1 to n, default 10
+//	around <leaderboard> <member> [page_size]  members around member
+//	rank <leaderboard> <member>                score and rank of member
+//	score <leaderboard> <member>               score of member
+//	percentile <leaderboard> <member>          percentile of member
+//	set <leaderboard> <member> <score> [data]  rank member
+//	incr <leaderboard> <member> <delta>        add delta to the score of member
+//	remove <leaderboard> <member>...           remove members
+//	trim <leaderboard> <rank>                  remove the members ranked below rank
+//	prune <leaderboard> [idle]                 remove the expired members, and those idle longer than idle, e.g. 720h
+//	delete <leaderboard>                       delete the leaderboard
+//	export <leaderboard>                       every member, as csv unless -o json
+//	import <leaderboard> [file]                rank the members of a csv or json export, from file or stdin
+//
+// trim, prune and delete ask for confirmation on stdin unless -yes is given.
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	rank "github.com/jaksal/leaderboard"
+	"github.com/jaksal/leaderboard/cmd/internal/cmdflags"
+)
+
+// batchSize : members read or written per call by export and import.
+// export writes every batch before reading the next.
+const batchSize = 1000
+
+// cli : a command on one leaderboard.
+type cli struct {
+	lb         *rank.Leaderboard
+	memberData bool
+	format     string
+	yes        bool
+	stdin      io.Reader
+	stdout     io.Writer
+}
+
+// command : a subcommand taking between min and max arguments after the leaderboard. max -1 takes any.
+type command struct {
+	usage string
+	min   int
+	max   int
+	run   func(c *cli, ctx context.Context, args []string) error
+}
+
+var commands = map[string]*command{
+	"top":        {"top <leaderboard> [n]", 0, 1, (*cli).top},
+	"around":     {"around <leaderboard> <member> [page_size]", 1, 2, (*cli).around},
+	"rank":       {"rank <leaderboard> <member>", 1, 1, (*cli).rank},
+	"score":      {"score <leaderboard> <member>", 1, 1, (*cli).score},
+	"percentile": {"percentile <leaderboard> <member>", 1, 1, (*cli).percentile},
+	"set":        {"set <leaderboard> <member> <score> [data]", 2, 3, (*cli).set},
+	"incr":       {"incr <leaderboard> <member> <delta>", 2, 2, (*cli).incr},
+	"remove":     {"remove <leaderboard> <member>...", 1, -1, (*cli).remove},
+	"trim":       {"trim <leaderboard> <rank>", 1, 1, (*cli).trim},
+	"prune":      {"prune <leaderboard> [idle]", 0, 1, (*cli).prune},
+	"delete":     {"delete <leaderboard>", 0, 0, (*cli).delete},
+	"export":     {"export <leaderboard>", 0, 0, (*cli).export},
+	"import":     {"import <leaderboard> [file]", 0, 1, (*cli).importMembers},
+}
+
+func main() {
+	redisAddr := flag.String("redis", "127.0.0.1:6379", "redis server address")
+	leaderboard := cmdflags.LeaderboardFlags("show and import the member data")
+	format := flag.String("o", "table", "output format: table, json or csv")
+	yes := flag.Bool("yes", false, "do not ask before trim, prune and delete")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() < 2 {
+		usage()
+		os.Exit(2)
+	}
+	options, err := leaderboard.Options()
+	if err != nil {
+		fail(err)
+	}
+	if *format != "table" && *format != "json" && *format != "csv" {
+		fail(fmt.Errorf("unknown output format %q", *format))
+	}
+
+	backend := rank.NewRedisBackend(rank.NewPool(*redisAddr))
+	defer backend.Close()
+
+	c := &cli{
+		lb:         rank.NewLeaderboardWithBackend(backend, flag.Arg(1), options...),
+		memberData: leaderboard.MemberData,
+		format:     *format,
+		yes:        *yes,
+		stdin:      os.Stdin,
+		stdout:     os.Stdout,
+	}
+	if err := c.run(context.Background(), flag.Arg(0), flag.Args()[2:]); err != nil {
+		backend.Close()
+		fail(err)
+	}
+}
+
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintln(out, "usage: leaderboard [flags] <command> <leaderboard> [args]")
+	fmt.Fprintln(out, "\ncommands:")
+	for _, name := range []string{"top", "around", "rank", "score", "percentile", "set", "incr", "remove", "trim", "prune", "delete", "export", "import"} {
+		fmt.Fprintln(out, "  "+commands[name].usage)
+	}
+	fmt.Fprintln(out, "\nflags:")
+	flag.PrintDefaults()
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "leaderboard:", err)
+	os.Exit(1)
+}
+
+// run : run the command name with args.
+func (c *cli) run(ctx context.Context, name string, args []string) error {
+	cmd, ok := commands[name]
+	if !ok {
+		return fmt.Errorf("unknown command %q", name)
+	}
+	if len(args) < cmd.min || (cmd.max >= 0 && len(args) > cmd.max) {
+		return fmt.Errorf("usage: leaderboard %s", cmd.usage)
+	}
+	return cmd.run(c, ctx, args)
+}
+
+// notFound : a readable error for a missing member.
+func notFound(member string, err error) error {
+	if errors.Is(err, rank.ErrMemberNotFound) {
+		return fmt.Errorf("%s: member not found", member)
+	}
+	return err
+}
+
+// confirm : ask the user to type yes before a destructive command.
+func (c *cli) confirm(prompt string) error {
+	if c.yes {
+		return nil
+	}
+	fmt.Fprintf(c.stdout, "%s type yes to continue: ", prompt)
+	answer, _ := bufio.NewReader(c.stdin).ReadString('\n')
+	if strings.TrimSpace(answer) != "yes" {
+		return errors.New("aborted")
+	}
+	return nil
+}
+
+func intArg(args []string, i int, name string, def int) (int, error) {
+	if len(args) <= i {
+		return def, nil
+	}
+	n, err := strconv.Atoi(args[i])
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%s: %q is not a positive integer", name, args[i])
+	}
+	return n, nil
+}
+
+func floatArg(args []string, i int, name string) (float64, error) {
+	f, err := strconv.ParseFloat(args[i], 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %q is not a number", name, args[i])
+	}
+	return f, nil
+}
+
+func (c *cli) top(ctx context.Context, args []string) error {
+	n, err := intArg(args, 0, "n", 10)
+	if err != nil {
+		return err
+	}
+	members, err := c.lb.Top(ctx, n)
+	if err != nil {
+		return err
+	}
+	return c.writeStandings(members)
+}
+
+func (c *cli) around(ctx context.Context, args []string) error {
+	pageSize, err := intArg(args, 1, "page_size", rank.DEFAULT_PAGESIZE)
+	if err != nil {
+		return err
+	}
+	members, err := c.lb.AroundMe(ctx, args[0], pageSize)
+	if err != nil {
+		return notFound(args[0], err)
+	}
+	return c.writeStandings(members)
+}
+
+func (c *cli) rank(ctx context.Context, args []string) error {
+	member, err := c.lb.ScoreAndRankFor(ctx, args[0])
+	if err != nil {
+		return notFound(args[0], err)
+	}
+	return c.writeStandings([]*rank.RankScore{member})
+}
+
+func (c *cli) score(ctx context.Context, args []string) error {
+	score, err := c.lb.ScoreFor(ctx, args[0])
+	if err != nil {
+		return notFound(args[0], err)
+	}
+	return c.writeValue("score", score)
+}
+
+func (c *cli) percentile(ctx context.Context, args []string) error {
+	percentile, err := c.lb.PercentileFor(ctx, args[0])
+	if err != nil {
+		return notFound(args[0], err)
+	}
+	return c.writeValue("percentile", percentile)
+}
+
+func (c *cli) set(ctx context.Context, args []string) error {
+	score, err := floatArg(args, 1, "score")
+	if err != nil {
+		return err
+	}
+	if len(args) > 2 {
+		err = c.lb.RankMemberWithData(ctx, args[0], score, args[2])
+	} else {
+		err = c.lb.RankMember(ctx, args[0], score)
+	}
+	if err != nil {
+		return err
+	}
+	return c.rank(ctx, args[:1])
+}
+
+func (c *cli) incr(ctx context.Context, args []string) error {
+	delta, err := floatArg(args, 1, "delta")
+	if err != nil {
+		return err
+	}
+	if err := c.lb.ChangeScoreFor(ctx, args[0], delta); err != nil {
+		return err
+	}
+	return c.rank(ctx, args[:1])
+}
+
+func (c *cli) remove(ctx context.Context, args []string) error {
+	removed := 0
+	for _, member := range args {
+		ok, err := c.lb.CheckMember(ctx, member)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if err := c.lb.RemoveMember(ctx, member); err != nil {
+			return err
+		}
+		removed++
+	}
+	return c.writeValue("removed", removed)
+}
+
+func (c *cli) trim(ctx context.Context, args []string) error {
+	keep, err := intArg(args, 0, "rank", 0)
+	if err != nil {
+		return err
+	}
+	total, err := c.lb.TotalMembers(ctx)
+	if err != nil {
+		return err
+	}
+	if total <= keep {
+		return c.writeValue("removed", 0)
+	}
+
+	if err := c.confirm(fmt.Sprintf("remove the %d members of %s ranked below %d?", total-keep, c.lb.Name, keep)); err != nil {
+		return err
+	}
+	removed, err := c.lb.RemoveMembersOutsideRank(ctx, keep)
+	if err != nil {
+		return err
+	}
+	return c.writeValue("removed", removed)
+}
+
+func (c *cli) prune(ctx context.Context, args []string) error {
+	var idle time.Duration
+	if len(args) > 0 {
+		d, err := time.ParseDuration(args[0])
+		if err != nil || d <= 0 {
+			return fmt.Errorf("idle: %q is not a positive duration", args[0])
+		}
+		idle = d
+	}
+
+	prompt := fmt.Sprintf("remove the expired members of %s?", c.lb.Name)
+	if idle > 0 {
+		prompt = fmt.Sprintf("remove the expired members of %s and those idle for more than %v?", c.lb.Name, idle)
+	}
+	if err := c.confirm(prompt); err != nil {
+		return err
+	}
+	removed, err := c.lb.PruneExpired(ctx)
+	if err != nil {
+		return err
+	}
+	if idle > 0 {
+		inactive, err := c.lb.PruneInactive(ctx, idle)
+		if err != nil {
+			return err
+		}
+		removed += inactive
+	}
+	return c.writeValue("removed", removed)
+}
+
+func (c *cli) delete(ctx context.Context, args []string) error {
+	total, err := c.lb.TotalMembers(ctx)
+	if err != nil {
+		return err
+	}
+	if err := c.confirm(fmt.Sprintf("delete %s and its %d members?", c.lb.Name, total)); err != nil {
+		return err
+	}
+	if err := c.lb.Delete(ctx); err != nil {
+		return err
+	}
+	return c.writeValue("removed", total)
+}
+
+// export : every member as csv or json, which import reads back. a table is written as csv.
+// pages follow each other by cursor, so members moving during the export are neither repeated nor skipped.
+func (c *cli) export(ctx context.Context, args []string) error {
+	format := c.format
+	if format != "json" {
+		format = "csv"
+	}
+	w := c.newStandingsWriter(format)
+	for cursor := ""; ; {
+		page, err := c.lb.MembersByCursor(ctx, cursor, batchSize)
+		if err != nil {
+			return err
+		}
+		if err := w.write(page.Members); err != nil {
+			return err
+		}
+		if cursor = page.Next; cursor == "" {
+			break
+		}
+	}
+	return w.close()
+}
+
+func (c *cli) importMembers(ctx context.Context, args []string) error {
+	in := c.stdin
+	if len(args) > 0 && args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	records, err := readStandings(in)
+	if err != nil {
+		return err
+	}
+	batch := make([]*rank.RankScore, 0, len(records))
+	for _, record := range records {
+		batch = append(batch, rank.NewRankScore(record.Member, record.Score))
+	}
+	if _, err := c.lb.RankMembersBatch(ctx, batch, rank.BatchOptions{ChunkSize: batchSize}); err != nil {
+		return err
+	}
+
+	if c.memberData {
+		data := make(map[string]string, len(records))
+		for _, record := range records {
+			if record.Data != "" {
+				data[record.Member] = record.Data
+			}
+		}
+		if err := c.lb.UpdateMembersData(ctx, data); err != nil {
+			return err
+		}
+	}
+	return c.writeValue("imported", len(records))
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	rank "github.com/jaksal/leaderboard"
+)
+
+const lbName = "test_cli"
+
+func newCLI(b rank.Backend, format string, stdin string) (*cli, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	return &cli{
+		lb:         rank.NewLeaderboardWithBackend(b, lbName, rank.WithMemberData()),
+		memberData: true,
+		format:     format,
+		stdin:      strings.NewReader(stdin),
+		stdout:     out,
+	}, out
+}
+
+func TestCommands(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	ctx := context.Background()
+	b := rank.NewMemoryBackend()
+
+	c, out := newCLI(b, "table", "")
+	for i, args := range [][]string{
+		{"set", "member_1", "10", "first"},
+		{"set", "member_2", "20"},
+		{"set", "member_3", "30"},
+		{"incr", "member_1", "25"},
+	} {
+		if err := c.run(ctx, args[0], args[1:]); err != nil {
+			t.Error("CLI "+args[0]+" Err!", i, err)
+		}
+	}
+	out.Reset()
+	if err := c.run(ctx, "top", []string{"2"}); err != nil || out.String() != "RANK  MEMBER    SCORE  DATA\n1     member_1  35     first\n2     member_3  30     \n" {
+		t.Errorf("CLI top Err! %q %v", out.String(), err)
+	}
+
+	c, out = newCLI(b, "json", "")
+	if err := c.run(ctx, "score", []string{"member_2"}); err != nil || out.String() != "{\"score\":20}\n" {
+		t.Errorf("CLI score Err! %q %v", out.String(), err)
+	}
+	out.Reset()
+	var standings []*standing
+	if err := c.run(ctx, "around", []string{"member_3", "3"}); err != nil || json.Unmarshal(out.Bytes(), &standings) != nil || len(standings) != 3 {
+		t.Errorf("CLI around Err! %q %v", out.String(), err)
+	}
+	if err := c.run(ctx, "rank", []string{"unknown"}); err == nil || err.Error() != "unknown: member not found" {
+		t.Error("CLI rank Err!", err)
+	}
+	if err := c.run(ctx, "percentile", []string{"unknown"}); err == nil {
+		t.Error("CLI percentile Err!", err)
+	}
+	if err := c.run(ctx, "top", []string{"1", "2"}); err == nil {
+		t.Error("CLI usage Err!", err)
+	}
+
+	// export then import into another leaderboard.
+	c, out = newCLI(b, "csv", "")
+	if err := c.run(ctx, "export", nil); err != nil || out.String() != "rank,member,score,data\n1,member_1,35,first\n2,member_3,30,\n3,member_2,20,\n" {
+		t.Errorf("CLI export Err! %q %v", out.String(), err)
+	}
+	exported := out.String()
+	c, out = newCLI(b, "table", "")
+	if err := c.run(ctx, "export", nil); err != nil || out.String() != exported {
+		t.Errorf("CLI export table Err! %q %v", out.String(), err)
+	}
+	c, out = newCLI(b, "json", "")
+	standings = nil
+	if err := c.run(ctx, "export", nil); err != nil || json.Unmarshal(out.Bytes(), &standings) != nil || len(standings) != 3 ||
+		standings[0].Member != "member_1" || standings[0].Data != "first" || standings[2].Rank != 3 {
+		t.Errorf("CLI export json Err! %q %v", out.String(), err)
+	}
+	imported, _ := newCLI(b, "table", exported)
+	imported.lb = rank.NewLeaderboardWithBackend(b, lbName+"_imported", rank.WithMemberData())
+	defer imported.lb.Delete(ctx)
+	if err := imported.run(ctx, "import", nil); err != nil {
+		t.Error("CLI import Err!", err)
+	}
+	if m, err := imported.lb.ScoreAndRankFor(ctx, "member_1"); err != nil || m.GetScore() != 35 || m.GetData() != "first" {
+		t.Error("CLI import Err!", m, err)
+	}
+	jsonImport, _ := newCLI(b, "table", `[{"member":"member_4","score":1}]`)
+	jsonImport.lb = imported.lb
+	if err := jsonImport.run(ctx, "import", nil); err != nil {
+		t.Error("CLI import json Err!", err)
+	}
+	if total, _ := imported.lb.TotalMembers(ctx); total != 4 {
+		t.Error("CLI import json Err!", total)
+	}
+
+	// trim and delete ask first.
+	c, _ = newCLI(b, "table", "no\n")
+	if err := c.run(ctx, "trim", []string{"2"}); err == nil || err.Error() != "aborted" {
+		t.Error("CLI trim Err!", err)
+	}
+	c, out = newCLI(b, "table", "yes\n")
+	if err := c.run(ctx, "trim", []string{"2"}); err != nil || !strings.HasSuffix(out.String(), "1\n") {
+		t.Errorf("CLI trim Err! %q %v", out.String(), err)
+	}
+	c, out = newCLI(b, "table", "")
+	c.yes = true
+	c.lb.ExpireMember(ctx, "member_3", -time.Minute)
+	if err := c.run(ctx, "prune", []string{"720h"}); err != nil || out.String() != "1\n" {
+		t.Errorf("CLI prune Err! %q %v", out.String(), err)
+	}
+	if err := c.run(ctx, "prune", []string{"soon"}); err == nil {
+		t.Error("CLI prune Err!", err)
+	}
+	if err := c.run(ctx, "remove", []string{"member_3", "unknown"}); err != nil {
+		t.Error("CLI remove Err!", err)
+	}
+	if err := c.run(ctx, "delete", nil); err != nil {
+		t.Error("CLI delete Err!", err)
+	}
+	if total, _ := c.lb.TotalMembers(ctx); total != 0 {
+		t.Error("CLI delete Err!", total)
+	}
+}
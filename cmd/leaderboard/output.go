@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	rank "github.com/jaksal/leaderboard"
+)
+
+// standing : a member as written by export and read by import.
+type standing struct {
+	Rank   int     `json:"rank,omitempty"`
+	Member string  `json:"member"`
+	Score  float64 `json:"score"`
+	Data   string  `json:"data,omitempty"`
+}
+
+func formatScore(score float64) string {
+	return strconv.FormatFloat(score, 'f', -1, 64)
+}
+
+func standingOf(m *rank.RankScore) *standing {
+	return &standing{Rank: m.GetRank(), Member: m.Member, Score: m.GetScore(), Data: m.GetData()}
+}
+
+// standingsWriter : members written a batch at a time, as one csv table or one json array.
+type standingsWriter struct {
+	format     string
+	memberData bool
+	out        io.Writer
+	csv        *csv.Writer
+	written    int
+}
+
+// newStandingsWriter : writer of the members in format, json or csv.
+func (c *cli) newStandingsWriter(format string) *standingsWriter {
+	return &standingsWriter{format: format, memberData: c.memberData, out: c.stdout}
+}
+
+// write : a batch of members, after the header or the opening of the array for the first one.
+func (w *standingsWriter) write(members []*rank.RankScore) error {
+	if w.format == "json" {
+		for _, m := range members {
+			value, err := json.MarshalIndent(standingOf(m), "  ", "  ")
+			if err != nil {
+				return err
+			}
+			sep := ",\n  "
+			if w.written == 0 {
+				sep = "[\n  "
+			}
+			if _, err := fmt.Fprintf(w.out, "%s%s", sep, value); err != nil {
+				return err
+			}
+			w.written++
+		}
+		return nil
+	}
+
+	if w.csv == nil {
+		w.csv = csv.NewWriter(w.out)
+		header := []string{"rank", "member", "score"}
+		if w.memberData {
+			header = append(header, "data")
+		}
+		w.csv.Write(header)
+	}
+	for _, m := range members {
+		s := standingOf(m)
+		record := []string{strconv.Itoa(s.Rank), s.Member, formatScore(s.Score)}
+		if w.memberData {
+			record = append(record, s.Data)
+		}
+		w.csv.Write(record)
+		w.written++
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+// close : end the json array, or write the csv header of an empty export.
+func (w *standingsWriter) close() error {
+	if w.format == "json" {
+		end := "\n]\n"
+		if w.written == 0 {
+			end = "[]\n"
+		}
+		_, err := io.WriteString(w.out, end)
+		return err
+	}
+	if w.csv == nil {
+		return w.write(nil)
+	}
+	return nil
+}
+
+// writeStandings : members in the output format.
+func (c *cli) writeStandings(members []*rank.RankScore) error {
+	if c.format == "json" || c.format == "csv" {
+		w := c.newStandingsWriter(c.format)
+		if err := w.write(members); err != nil {
+			return err
+		}
+		return w.close()
+	}
+
+	standings := make([]*standing, 0, len(members))
+	for _, m := range members {
+		standings = append(standings, standingOf(m))
+	}
+	w := tabwriter.NewWriter(c.stdout, 0, 4, 2, ' ', 0)
+	header := "RANK\tMEMBER\tSCORE"
+	if c.memberData {
+		header += "\tDATA"
+	}
+	fmt.Fprintln(w, header)
+	for _, s := range standings {
+		row := fmt.Sprintf("%d\t%s\t%s", s.Rank, s.Member, formatScore(s.Score))
+		if c.memberData {
+			row += "\t" + s.Data
+		}
+		fmt.Fprintln(w, row)
+	}
+	return w.Flush()
+}
+
+// writeValue : a single named value in the output format.
+func (c *cli) writeValue(name string, value interface{}) error {
+	switch c.format {
+	case "json":
+		return json.NewEncoder(c.stdout).Encode(map[string]interface{}{name: value})
+	case "csv":
+		_, err := fmt.Fprintf(c.stdout, "%s\n%v\n", name, value)
+		return err
+	}
+	_, err := fmt.Fprintln(c.stdout, value)
+	return err
+}
+
+// readStandings : members of a json or csv export. csv needs the member and score columns.
+func readStandings(in io.Reader) ([]*standing, error) {
+	content, err := io.ReadAll(in)
+	if err != nil {
+		return nil, err
+	}
+	if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] == '[' {
+		var standings []*standing
+		if err := json.Unmarshal(trimmed, &standings); err != nil {
+			return nil, fmt.Errorf("import: %v", err)
+		}
+		for i, s := range standings {
+			if s.Member == "" {
+				return nil, fmt.Errorf("import: member %d: empty name", i+1)
+			}
+		}
+		return standings, nil
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(content)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("import: %v", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	memberColumn, ok := columns["member"]
+	if !ok {
+		return nil, fmt.Errorf("import: csv header without a member column")
+	}
+	scoreColumn, ok := columns["score"]
+	if !ok {
+		return nil, fmt.Errorf("import: csv header without a score column")
+	}
+	dataColumn, hasData := columns["data"]
+
+	standings := make([]*standing, 0, len(records)-1)
+	for i, record := range records[1:] {
+		s := &standing{Member: record[memberColumn]}
+		if s.Member == "" {
+			return nil, fmt.Errorf("import: line %d: empty member", i+2)
+		}
+		if s.Score, err = strconv.ParseFloat(record[scoreColumn], 64); err != nil {
+			return nil, fmt.Errorf("import: line %d: %q is not a number", i+2, record[scoreColumn])
+		}
+		if hasData {
+			s.Data = record[dataColumn]
+		}
+		standings = append(standings, s)
+	}
+	return standings, nil
+}
@@ -0,0 +1,222 @@
+package rank
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+	"strings"
+)
+
+// CursorPage : a page of members read by MembersByCursor.
+type CursorPage struct {
+	Members []*RankScore
+	// Next : cursor of the page after this one. empty on the last page.
+	Next string
+	// Prev : cursor of the page before this one. empty on the first page.
+	Prev string
+}
+
+// cursor : position in the leaderboard order right after member, or right before it when backward.
+// it holds the score of member when the page was read, so it stays in place when member moves or leaves.
+type cursor struct {
+	backward bool
+	score    float64
+	member   string
+	// time : submission time of member, orders the ties of RankEarliestFirst.
+	time int64
+}
+
+// String : opaque form of the cursor, safe in urls.
+func (c *cursor) String() string {
+	direction := "n"
+	if c.backward {
+		direction = "p"
+	}
+	s := direction + "|" + strconv.FormatFloat(c.score, 'g', -1, 64) + "|" + strconv.FormatInt(c.time, 10) + "|" + c.member
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
+
+// parseCursor : cursor of String. ErrInvalidCursor when s was not made by String.
+func parseCursor(s string) (*cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	fields := strings.SplitN(string(b), "|", 4)
+	if len(fields) != 4 || (fields[0] != "n" && fields[0] != "p") {
+		return nil, ErrInvalidCursor
+	}
+
+	c := &cursor{backward: fields[0] == "p", member: fields[3]}
+	if c.score, err = strconv.ParseFloat(fields[1], 64); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if c.time, err = strconv.ParseInt(fields[2], 10, 64); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// follows : a member holding the score of the cursor comes after it in its direction, by the tie order of the leaderboard.
+// time is the submission time of member, used only by RankEarliestFirst.
+func (lb *Leaderboard) follows(c *cursor, member string, time int64) bool {
+	if member == c.member {
+		return false
+	}
+	// ties are ordered by name like ZREVRANGE, or ZRANGE when ascending.
+	after := (member > c.member) == (lb.order == Ascending)
+	if lb.rankingMode == RankEarliestFirst && time != c.time {
+		after = time > c.time
+	}
+	return after != c.backward
+}
+
+// entriesFrom : up to count entries next to the cursor, nearest first.
+// the ties of the cursor score are read by chunks of count until the cursor member is passed.
+func (lb *Leaderboard) entriesFrom(ctx context.Context, c *cursor, count int) ([]Entry, error) {
+	at := scoreBound(c.score, false)
+	var entries []Entry
+
+	if lb.rankingMode == RankEarliestFirst {
+		// ties are ordered by time, not by the backend. the whole tie is read and filtered.
+		ties, err := lb.byScore(ctx, at, at)
+		if err != nil {
+			return nil, err
+		}
+		members := make([]string, 0, len(ties))
+		for _, entry := range ties {
+			members = append(members, entry.Member)
+		}
+		timestamps, err := lb.timestamps(ctx, members...)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range ties {
+			if lb.follows(c, entry.Member, timestamps[entry.Member]) {
+				entries = append(entries, entry)
+			}
+		}
+	} else {
+		for offset := 0; len(entries) < count; offset += count {
+			ties, err := lb.byScoreLimit(ctx, at, at, offset, count, c.backward)
+			if err != nil {
+				return nil, err
+			}
+			for _, entry := range ties {
+				if lb.follows(c, entry.Member, 0) {
+					entries = append(entries, entry)
+				}
+			}
+			if len(ties) < count {
+				break
+			}
+		}
+	}
+	if len(entries) >= count {
+		return entries, nil
+	}
+
+	min, max := lb.worseRange(c.score)
+	if c.backward {
+		min, max = lb.betterRange(c.score)
+	}
+	rest, err := lb.byScoreLimit(ctx, min, max, 0, count-len(entries), c.backward)
+	if err != nil {
+		return nil, err
+	}
+	if lb.rankingMode == RankEarliestFirst && len(rest) == count-len(entries) {
+		// the last tie may be cut anywhere by the backend order. it is read whole and cut again by time.
+		edge := rest[len(rest)-1].Score
+		tie, err := lb.byScore(ctx, scoreBound(edge, false), scoreBound(edge, false))
+		if err != nil {
+			return nil, err
+		}
+		for len(rest) > 0 && rest[len(rest)-1].Score == edge {
+			rest = rest[:len(rest)-1]
+		}
+		rest = append(rest, tie...)
+	}
+	return append(entries, rest...), nil
+}
+
+// cursorAt : cursor after entry, or before it when backward.
+func (lb *Leaderboard) cursorAt(entry Entry, backward bool, timestamps map[string]int64) string {
+	c := &cursor{backward: backward, score: entry.Score, member: entry.Member, time: timestamps[entry.Member]}
+	return c.String()
+}
+
+// MembersByCursor : Retrieve the page of members next to a cursor of a previous page. an empty cursor reads the first page.
+// pages follow each other from where the previous one ended, so members moving between reads are neither repeated
+// nor skipped like with Members. returns ErrInvalidCursor for a cursor not made by this package.
+func (lb *Leaderboard) MembersByCursor(ctx context.Context, from string, pageSize int) (*CursorPage, error) {
+	if pageSize < 1 {
+		pageSize = DEFAULT_PAGESIZE
+	}
+
+	var c *cursor
+	var entries []Entry
+	var err error
+	if from == "" {
+		entries, err = lb.rangeByPosition(ctx, 0, pageSize-1)
+	} else {
+		if c, err = parseCursor(from); err != nil {
+			return nil, err
+		}
+		entries, err = lb.entriesFrom(ctx, c, pageSize)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// entries are nearest first. the page keeps the pageSize nearest in the leaderboard order.
+	backward := c != nil && c.backward
+	if backward {
+		for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+			entries[i], entries[j] = entries[j], entries[i]
+		}
+	}
+	// the times ordering the ties also place the page and its cursors.
+	var timestamps map[string]int64
+	if lb.rankingMode == RankEarliestFirst && len(entries) > 0 {
+		members := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			members = append(members, entry.Member)
+		}
+		if timestamps, err = lb.timestamps(ctx, members...); err != nil {
+			return nil, err
+		}
+		lb.sortByTime(entries, timestamps)
+	}
+	if len(entries) > pageSize {
+		if backward {
+			entries = entries[len(entries)-pageSize:]
+		} else {
+			entries = entries[:pageSize]
+		}
+	}
+
+	page := &CursorPage{Members: []*RankScore{}}
+	if len(entries) == 0 {
+		return page, nil
+	}
+	first, last := entries[0], entries[len(entries)-1]
+	position, err := lb.positionOf(ctx, first, timestamps[first.Member])
+	if err != nil {
+		return nil, err
+	}
+	if page.Members, err = lb.rankedEntries(ctx, entries, position, nil); err != nil {
+		return nil, err
+	}
+	total, err := lb.TotalMembers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if position > 0 {
+		page.Prev = lb.cursorAt(first, true, timestamps)
+	}
+	if position+len(entries) < total {
+		page.Next = lb.cursorAt(last, false, timestamps)
+	}
+	return page, nil
+}
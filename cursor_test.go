@@ -0,0 +1,188 @@
+package rank
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+// walkCursor : every page read by MembersByCursor from cursor, following Next or Prev.
+func walkCursor(t *testing.T, lb *Leaderboard, cursor string, pageSize int, backward bool) []*RankScore {
+	ctx := context.Background()
+	var members []*RankScore
+	for i := 0; i < 100; i++ {
+		page, err := lb.MembersByCursor(ctx, cursor, pageSize)
+		if err != nil {
+			t.Fatal("Leaderboard MembersByCursor Err!", err)
+		}
+		if backward {
+			members = append(page.Members, members...)
+			cursor = page.Prev
+		} else {
+			members = append(members, page.Members...)
+			cursor = page.Next
+		}
+		if cursor == "" {
+			return members
+		}
+	}
+	t.Fatal("Leaderboard MembersByCursor Err! no last page")
+	return nil
+}
+
+func sameMembers(a []*RankScore, b []*RankScore) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Member != b[i].Member || a[i].score != b[i].score || a[i].rank != b[i].rank {
+			return false
+		}
+	}
+	return true
+}
+
+func testMembersByCursor(t *testing.T, b Backend) {
+	ctx := context.Background()
+	lb := NewLeaderboardWithBackend(b, lbName)
+	defer lb.Delete(ctx)
+
+	for i := 1; i <= 10; i++ {
+		lb.RankMember(ctx, fmt.Sprintf("member_%02d", i), float64(i))
+	}
+
+	page1, err := lb.MembersByCursor(ctx, "", 3)
+	if err != nil || len(page1.Members) != 3 || page1.Members[0].Member != "member_10" || page1.Prev != "" || page1.Next == "" {
+		t.Fatal("Leaderboard MembersByCursor Err!", page1, err)
+	}
+
+	// the leader drops to the bottom between two pages. Members(2) would skip member_07.
+	lb.RankMember(ctx, "member_10", 0)
+	page2, err := lb.MembersByCursor(ctx, page1.Next, 3)
+	if err != nil || len(page2.Members) != 3 || page2.Members[0].Member != "member_07" || page2.Members[0].rank != 3 {
+		t.Fatal("Leaderboard MembersByCursor Next Err!", page2, err)
+	}
+
+	// backward from the second page: what is above member_07 now.
+	prev, err := lb.MembersByCursor(ctx, page2.Prev, 3)
+	if err != nil || len(prev.Members) != 2 || prev.Members[0].Member != "member_09" || prev.Members[1].Member != "member_08" ||
+		prev.Members[0].rank != 1 || prev.Prev != "" || prev.Next == "" {
+		t.Error("Leaderboard MembersByCursor Prev Err!", prev, err)
+	}
+
+	last, err := lb.MembersByCursor(ctx, page2.Next, 5)
+	if err != nil || len(last.Members) != 5 || last.Members[4].Member != "member_10" || last.Members[4].rank != 10 || last.Next != "" {
+		t.Error("Leaderboard MembersByCursor Last Err!", last, err)
+	}
+
+	// the cursor member left the leaderboard. the next page starts where it was.
+	lb.RemoveMember(ctx, "member_05")
+	if page, err := lb.MembersByCursor(ctx, page2.Next, 2); err != nil || len(page.Members) != 2 || page.Members[0].Member != "member_04" || page.Members[0].rank != 5 {
+		t.Error("Leaderboard MembersByCursor Removed Err!", page, err)
+	}
+
+	if _, err := lb.MembersByCursor(ctx, "not a cursor", 3); !errors.Is(err, ErrInvalidCursor) {
+		t.Error("Leaderboard MembersByCursor Invalid Err!", err)
+	}
+
+	empty := NewLeaderboardWithBackend(b, lbName+"_empty")
+	if page, err := empty.MembersByCursor(ctx, "", 3); err != nil || len(page.Members) != 0 || page.Next != "" || page.Prev != "" {
+		t.Error("Leaderboard MembersByCursor Empty Err!", page, err)
+	}
+}
+
+func TestMembersByCursor(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	testMembersByCursor(t, backend)
+	testMembersByCursor(t, NewMemoryBackend())
+}
+
+func testMembersByCursorTies(t *testing.T, b Backend) {
+	ctx := context.Background()
+	options := map[string][]Option{
+		"standard":     nil,
+		"dense":        {WithRankingMode(RankDense)},
+		"ordinal":      {WithRankingMode(RankOrdinal)},
+		"earliest":     {WithRankingMode(RankEarliestFirst)},
+		"ascending":    {WithSortOrder(Ascending)},
+		"asc_earliest": {WithSortOrder(Ascending), WithRankingMode(RankEarliestFirst)},
+	}
+	for name, opts := range options {
+		lb := NewLeaderboardWithBackend(b, lbName, opts...)
+		for i, score := range []float64{5, 3, 3, 3, 3, 3, 1, 7, 7, 2, 3} {
+			lb.RankMember(ctx, fmt.Sprintf("member_%d", (i*7)%11), score)
+		}
+
+		all, _ := lb.AllMembers(ctx)
+		for pageSize := 1; pageSize <= 4; pageSize++ {
+			if members := walkCursor(t, lb, "", pageSize, false); !sameMembers(members, all) {
+				t.Error("Leaderboard MembersByCursor Ties Err!", name, pageSize, members, all)
+			}
+
+			// back from the last page to the first.
+			first, _ := lb.MembersByCursor(ctx, "", pageSize)
+			cursor := first.Next
+			for cursor != "" {
+				page, _ := lb.MembersByCursor(ctx, cursor, pageSize)
+				if page.Next == "" {
+					cursor = page.Prev
+					break
+				}
+				cursor = page.Next
+			}
+			if cursor == "" {
+				continue
+			}
+			members := walkCursor(t, lb, cursor, pageSize, true)
+			if !sameMembers(members, all[:len(members)]) || len(members) < len(all)-pageSize {
+				t.Error("Leaderboard MembersByCursor Ties Prev Err!", name, pageSize, members, all)
+			}
+		}
+		lb.Delete(ctx)
+	}
+}
+
+func TestMembersByCursorTies(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	testMembersByCursorTies(t, backend)
+	testMembersByCursorTies(t, NewMemoryBackend())
+}
+
+func testMembersByCursorRemoved(t *testing.T, b Backend) {
+	ctx := context.Background()
+	options := map[string][]Option{
+		"standard":     nil,
+		"ascending":    {WithSortOrder(Ascending)},
+		"earliest":     {WithRankingMode(RankEarliestFirst)},
+		"asc_earliest": {WithSortOrder(Ascending), WithRankingMode(RankEarliestFirst)},
+	}
+	for name, opts := range options {
+		lb := NewLeaderboardWithBackend(b, lbName, opts...)
+		for i, score := range []float64{5, 3, 3, 3, 7, 3} {
+			lb.RankMember(ctx, fmt.Sprintf("member_%d", (i*5)%6), score)
+		}
+
+		// the first member of a page leaves before its position is read. the next one takes its place.
+		all, _ := lb.AllMembers(ctx)
+		for i, member := range all {
+			timestamps, _ := lb.timestamps(ctx, member.Member)
+			lb.RemoveMember(ctx, member.Member)
+			if position, err := lb.positionOf(ctx, Entry{Member: member.Member, Score: member.score}, timestamps[member.Member]); err != nil || position != i {
+				t.Error("Leaderboard MembersByCursor Removed Err!", name, member, position, err)
+			}
+			lb.RankMember(ctx, member.Member, member.score)
+			if lb.rankingMode == RankEarliestFirst {
+				lb.backend.HSet(ctx, lb.timestampKey(), map[string]string{member.Member: strconv.FormatInt(timestamps[member.Member], 10)})
+			}
+		}
+		lb.Delete(ctx)
+	}
+}
+
+func TestMembersByCursorRemoved(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	testMembersByCursorRemoved(t, backend)
+	testMembersByCursorRemoved(t, NewMemoryBackend())
+}
@@ -0,0 +1,108 @@
+package rank
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// decay : how the points of a leaderboard lose weight over time.
+// a point earned at t is stored as its value times weight(t) and read back divided by the weight of the time of
+// the read, so every stored score decays at the same pace and the order of the members needs no rewrite.
+type decay struct {
+	exponential bool
+	period      time.Duration
+	// mu : guards epoch, moved by Rebase.
+	mu    sync.RWMutex
+	epoch time.Time
+}
+
+// WithExponentialDecay : a point is worth half after every halfLife. e.g. trending boards.
+// epoch is the origin of the stored scores and must be the same for every writer. stored scores double every
+// halfLife after it and leave the float64 range after about 1000 half-lives. reads and writes then return
+// ErrDecayOverflow until Rebase moves the epoch forward.
+func WithExponentialDecay(halfLife time.Duration, epoch time.Time) Option {
+	return func(lb *Leaderboard) {
+		if halfLife > 0 {
+			lb.decay = &decay{exponential: true, period: halfLife, epoch: epoch}
+		}
+	}
+}
+
+// WithLinearDecay : a point earned at s is worth (1+s)/(1+t) at t, s and t in periods since epoch.
+// the weight of a point against new points falls linearly, and old points lose less as the board ages:
+// a point earned at the epoch is worth 1/(1+n) after n periods, one earned at n=9 is still worth 10/11 one period later.
+// epoch is the origin of the stored scores, before the first write and the same for every writer.
+func WithLinearDecay(period time.Duration, epoch time.Time) Option {
+	return func(lb *Leaderboard) {
+		if period > 0 {
+			lb.decay = &decay{period: period, epoch: epoch}
+		}
+	}
+}
+
+// weight : stored value of a point earned at t.
+func (d *decay) weight(t time.Time) float64 {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	elapsed := float64(t.Sub(d.epoch)) / float64(d.period)
+	if d.exponential {
+		return math.Exp2(elapsed)
+	}
+	return 1 + elapsed
+}
+
+// origin : epoch of the stored scores.
+func (d *decay) origin() time.Time {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.epoch
+}
+
+// decayErr : ErrDecayOverflow when the weight of now is out of the float64 range.
+func (lb *Leaderboard) decayErr() error {
+	if lb.decay == nil {
+		return nil
+	}
+	if w := lb.decay.weight(lb.now()); math.IsInf(w, 0) || !(w > 0) {
+		return ErrDecayOverflow
+	}
+	return nil
+}
+
+// stored : encode a score or a delta for a write. ErrDecayOverflow when its stored value is out of the float64 range.
+func (lb *Leaderboard) stored(score float64) (float64, error) {
+	if err := lb.decayErr(); err != nil {
+		return 0, err
+	}
+	value := lb.encode(score)
+	if lb.decay != nil && math.IsInf(value, 0) && !math.IsInf(score, 0) {
+		return 0, ErrDecayOverflow
+	}
+	return value, nil
+}
+
+// Rebase : Move the epoch of an exponentially decaying leaderboard to epoch, rescaling every stored score in one
+// atomic step so that the decayed scores stay the same. run it with an epoch close to now before the stored
+// scores overflow, or after ErrDecayOverflow. every leaderboard writing the board must use the new epoch
+// afterwards: writes encoded against the old epoch by other processes are off by the rescaling.
+// linear decay never overflows and is left as is.
+func (lb *Leaderboard) Rebase(ctx context.Context, epoch time.Time) error {
+	if lb.decay == nil || !lb.decay.exponential {
+		return nil
+	}
+
+	// writes of this leaderboard wait for the new epoch.
+	d := lb.decay
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	// stored scores times 1/weight(epoch).
+	exp := float64(d.epoch.Sub(epoch)) / float64(d.period)
+	if err := lb.backend.ZScaleExp2(ctx, lb.Name, exp); err != nil {
+		return err
+	}
+	d.epoch = epoch
+	return nil
+}
@@ -0,0 +1,149 @@
+package rank
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func testDecay(t *testing.T, b Backend) {
+	ctx := context.Background()
+	epoch := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	lb := NewLeaderboardWithBackend(b, lbName, WithExponentialDecay(time.Hour, epoch))
+	defer lb.Delete(ctx)
+	now := epoch
+	lb.now = func() time.Time { return now }
+
+	lb.RankMember(ctx, "member_1", 100)
+	now = now.Add(time.Hour)
+	if score, _ := lb.ScoreFor(ctx, "member_1"); score != 50 {
+		t.Error("Leaderboard Decay ScoreFor Err!", score)
+	}
+
+	// newer points count more.
+	lb.RankMember(ctx, "member_2", 60)
+	if rank, _ := lb.RankFor(ctx, "member_2"); rank != 1 {
+		t.Error("Leaderboard Decay RankFor Err!", rank)
+	}
+
+	now = now.Add(time.Hour)
+	if members, _ := lb.Members(ctx, 1, 10); len(members) != 2 || members[0].Member != "member_2" || members[0].score != 30 || members[1].score != 25 {
+		t.Error("Leaderboard Decay Members Err!", members)
+	}
+	lb.ChangeScoreFor(ctx, "member_1", 10)
+	if m, _ := lb.ScoreAndRankFor(ctx, "member_1"); m.score != 35 || m.rank != 1 {
+		t.Error("Leaderboard Decay ChangeScoreFor Err!", m)
+	}
+	if members, _ := lb.AroundMe(ctx, "member_2", 2); len(members) != 2 || members[1].Member != "member_2" || members[1].score != 30 {
+		t.Error("Leaderboard Decay AroundMe Err!", members)
+	}
+	if members, _ := lb.MembersFromScoreRange(ctx, 31, 40); len(members) != 1 || members[0].Member != "member_1" {
+		t.Error("Leaderboard Decay MembersFromScoreRange Err!", members)
+	}
+	lb.Delete(ctx)
+
+	// linear: a point is worth 1/(1+n) after n periods.
+	lb = NewLeaderboardWithBackend(b, lbName, WithLinearDecay(time.Hour, epoch))
+	now = epoch
+	lb.now = func() time.Time { return now }
+	lb.RankMember(ctx, "member_1", 100)
+	now = now.Add(time.Hour)
+	lb.RankMember(ctx, "member_2", 60)
+	if m, _ := lb.ScoreAndRankFor(ctx, "member_1"); m.score != 50 || m.rank != 2 {
+		t.Error("Leaderboard LinearDecay Err!", m)
+	}
+	now = now.Add(2 * time.Hour)
+	if m, _ := lb.ScoreAndRankFor(ctx, "member_2"); m.score != 30 || m.rank != 1 {
+		t.Error("Leaderboard LinearDecay Err!", m)
+	}
+}
+
+func TestDecay(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	testDecay(t, backend)
+	testDecay(t, NewMemoryBackend())
+}
+
+func testDecaySeason(t *testing.T, b Backend) {
+	ctx := context.Background()
+	epoch := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	lb := NewLeaderboardWithBackend(b, lbName, WithExponentialDecay(time.Hour, epoch))
+	defer lb.Delete(ctx)
+	defer lb.DeleteSeason(ctx, "2024-s1")
+	now := epoch
+	lb.now = func() time.Time { return now }
+
+	lb.RankMember(ctx, "member_1", 100)
+	now = now.Add(time.Hour)
+	if _, err := lb.Rollover(ctx, "2024-s1"); err != nil {
+		t.Fatal("Leaderboard Decay Rollover Err!", err)
+	}
+
+	// the final standings keep the scores of the archive time, across a Rebase of the live board.
+	now = now.Add(2 * time.Hour)
+	lb.Rebase(ctx, now)
+	archived, err := lb.Season(ctx, "2024-s1")
+	if err != nil {
+		t.Fatal("Leaderboard Decay Season Err!", err)
+	}
+	if score, err := archived.ScoreFor(ctx, "member_1"); err != nil || score != 50 {
+		t.Error("ArchivedLeaderboard Decay ScoreFor Err!", score, err)
+	}
+}
+
+func TestDecaySeason(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	testDecaySeason(t, backend)
+	testDecaySeason(t, NewMemoryBackend())
+}
+
+func testDecayOverflow(t *testing.T, b Backend) {
+	ctx := context.Background()
+	epoch := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	lb := NewLeaderboardWithBackend(b, lbName, WithExponentialDecay(time.Hour, epoch))
+	defer lb.Delete(ctx)
+	now := epoch.Add(1000 * time.Hour)
+	lb.now = func() time.Time { return now }
+
+	lb.RankMember(ctx, "member_1", 100)
+	if err := lb.RankMember(ctx, "member_2", math.MaxFloat64); !errors.Is(err, ErrDecayOverflow) {
+		t.Error("Leaderboard DecayOverflow RankMember Err!", err)
+	}
+
+	// past the float64 range of the weight.
+	now = epoch.Add(1100 * time.Hour)
+	if score, err := lb.ScoreFor(ctx, "member_1"); !errors.Is(err, ErrDecayOverflow) {
+		t.Error("Leaderboard DecayOverflow ScoreFor Err!", score, err)
+	}
+	if rank, err := lb.RankFor(ctx, "member_1"); !errors.Is(err, ErrDecayOverflow) {
+		t.Error("Leaderboard DecayOverflow RankFor Err!", rank, err)
+	}
+	if members, err := lb.Members(ctx, 1, 10); !errors.Is(err, ErrDecayOverflow) {
+		t.Error("Leaderboard DecayOverflow Members Err!", members, err)
+	}
+	if err := lb.RankMember(ctx, "member_2", 1); !errors.Is(err, ErrDecayOverflow) {
+		t.Error("Leaderboard DecayOverflow RankMember Err!", err)
+	}
+
+	if err := lb.Rebase(ctx, epoch.Add(1090*time.Hour)); err != nil {
+		t.Error("Leaderboard Rebase Err!", err)
+	}
+	if err := lb.RankMember(ctx, "member_2", 1); err != nil {
+		t.Error("Leaderboard Rebase RankMember Err!", err)
+	}
+	if m, err := lb.ScoreAndRankFor(ctx, "member_2"); err != nil || m.score != 1 || m.rank != 1 {
+		t.Error("Leaderboard Rebase ScoreAndRankFor Err!", m, err)
+	}
+	// 100 points 100 half-lives old, moved by more half-lives than a float64 factor can hold.
+	if m, err := lb.ScoreAndRankFor(ctx, "member_1"); err != nil || m.score != math.Exp2(-100)*100 || m.rank != 2 {
+		t.Error("Leaderboard Rebase ScoreAndRankFor Err!", m, err)
+	}
+}
+
+func TestDecayOverflow(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	testDecayOverflow(t, backend)
+	testDecayOverflow(t, NewMemoryBackend())
+}
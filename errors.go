@@ -0,0 +1,41 @@
+package rank
+
+import (
+	"errors"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+var (
+	// ErrMemberNotFound : the member is not in the leaderboard.
+	// errors.Is also matches it with redis.ErrNil, which lookups returned before.
+	ErrMemberNotFound error = &nilError{"rank: member not found"}
+	// ErrLeaderboardEmpty : the leaderboard has no member to answer from.
+	ErrLeaderboardEmpty = errors.New("rank: leaderboard empty")
+	// ErrInvalidRange : a rank, position or score range that selects nothing, e.g. an end before its start.
+	ErrInvalidRange = errors.New("rank: invalid range")
+	// ErrInvalidPercentile : a percentile outside 0..100.
+	ErrInvalidPercentile = errors.New("rank: invalid percentile")
+	// ErrInvalidName : a leaderboard name that is empty or names a key kept next to a leaderboard. see ValidName.
+	ErrInvalidName = errors.New("rank: invalid leaderboard name")
+	// ErrInvalidCursor : a cursor that was not made by MembersByCursor.
+	ErrInvalidCursor = errors.New("rank: invalid cursor")
+	// ErrDecayOverflow : the stored scores of a decaying leaderboard left the float64 range. see Rebase.
+	ErrDecayOverflow = errors.New("rank: decayed scores out of range, rebase the epoch")
+	// ErrSeasonNotFound : no season was archived under the ID.
+	// errors.Is also matches it with redis.ErrNil, which Season returned before.
+	ErrSeasonNotFound error = &nilError{"rank: season not found"}
+)
+
+// nilError : sentinel error that also matches redis.ErrNil.
+type nilError struct {
+	msg string
+}
+
+func (e *nilError) Error() string {
+	return e.msg
+}
+
+func (e *nilError) Is(target error) bool {
+	return target == redis.ErrNil
+}
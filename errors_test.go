@@ -0,0 +1,87 @@
+package rank
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+func testErrors(t *testing.T, b Backend) {
+	ctx := context.Background()
+	lb := NewLeaderboardWithBackend(b, lbName, WithMemberData())
+	defer lb.Delete(ctx)
+
+	if _, err := lb.MemberAt(ctx, 1); !errors.Is(err, ErrLeaderboardEmpty) {
+		t.Error("Leaderboard MemberAt Empty Err!", err)
+	}
+	if _, err := lb.ScoreForPercentile(ctx, 50); !errors.Is(err, ErrLeaderboardEmpty) {
+		t.Error("Leaderboard ScoreForPercentile Empty Err!", err)
+	}
+	if members, err := lb.Top(ctx, 10); err != nil || len(members) != 0 {
+		t.Error("Leaderboard Top Empty Err!", members, err)
+	}
+
+	lb.RankMember(ctx, "member_1", 10)
+	lb.RankMember(ctx, "member_2", 20)
+
+	if _, err := lb.ScoreFor(ctx, "jones"); !errors.Is(err, ErrMemberNotFound) {
+		t.Error("Leaderboard ScoreFor Err!", err)
+	}
+	if _, err := lb.RankFor(ctx, "jones"); !errors.Is(err, ErrMemberNotFound) {
+		t.Error("Leaderboard RankFor Err!", err)
+	}
+	if _, err := lb.ScoreAndRankFor(ctx, "jones"); !errors.Is(err, ErrMemberNotFound) {
+		t.Error("Leaderboard ScoreAndRankFor Err!", err)
+	}
+	if _, err := lb.AroundMe(ctx, "jones", 10); !errors.Is(err, ErrMemberNotFound) {
+		t.Error("Leaderboard AroundMe Err!", err)
+	}
+	if _, err := lb.PercentileFor(ctx, "jones"); !errors.Is(err, ErrMemberNotFound) {
+		t.Error("Leaderboard PercentileFor Err!", err)
+	}
+	if _, err := lb.MemberDataFor(ctx, "jones"); !errors.Is(err, ErrMemberNotFound) {
+		t.Error("Leaderboard MemberDataFor Err!", err)
+	}
+	// callers matching redis.ErrNil keep working.
+	if _, err := lb.ScoreFor(ctx, "jones"); !errors.Is(err, redis.ErrNil) {
+		t.Error("Leaderboard ScoreFor ErrNil Err!", err)
+	}
+
+	members, err := lb.RankedInList(ctx, []string{"member_1", "jones"})
+	if err != nil || len(members) != 2 || members[0].GetErr() != nil || !errors.Is(members[1].GetErr(), ErrMemberNotFound) {
+		t.Error("Leaderboard RankedInList Err!", members, err)
+	}
+
+	if _, err := lb.MemberAt(ctx, 3); !errors.Is(err, ErrInvalidRange) {
+		t.Error("Leaderboard MemberAt Range Err!", err)
+	}
+	if _, err := lb.MemberAt(ctx, 0); !errors.Is(err, ErrInvalidRange) {
+		t.Error("Leaderboard MemberAt Range Err!", err)
+	}
+	if _, err := lb.ScoreForPercentile(ctx, 101); !errors.Is(err, ErrInvalidPercentile) {
+		t.Error("Leaderboard ScoreForPercentile Err!", err)
+	}
+	if _, err := lb.MembersFromRankRange(ctx, 5, 2); !errors.Is(err, ErrInvalidRange) {
+		t.Error("Leaderboard MembersFromRankRange Err!", err)
+	}
+	if _, err := lb.MembersFromScoreRange(ctx, 30, 10); !errors.Is(err, ErrInvalidRange) {
+		t.Error("Leaderboard MembersFromScoreRange Err!", err)
+	}
+
+	for _, name := range []string{"", lb.memberDataKey(), lb.timestampKey(), lbName + ":top:10", lbName + ":season:2024-s1"} {
+		if err := ValidName(name); !errors.Is(err, ErrInvalidName) {
+			t.Error("ValidName Err!", name, err)
+		}
+	}
+	if err := ValidName(lbName); err != nil {
+		t.Error("ValidName Err!", err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	testErrors(t, backend)
+	testErrors(t, NewMemoryBackend())
+}
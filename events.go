@@ -0,0 +1,193 @@
+package rank
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// eventTransport : where a leaderboard publishes its events.
+type eventTransport int
+
+const (
+	noEvents eventTransport = iota
+	channelEvents
+	streamEvents
+)
+
+// EventType : kind of change of an Event.
+type EventType string
+
+const (
+	// EventRanked : the member got a new score.
+	EventRanked EventType = "ranked"
+	// EventRemoved : the member left the leaderboard.
+	EventRemoved EventType = "removed"
+	// EventEntered : the member crossed a watched threshold. see WithTopWatch and WithScoreWatch.
+	EventEntered EventType = "entered"
+	// EventLeft : the member fell back behind a watched threshold.
+	EventLeft EventType = "left"
+)
+
+// Event : change of a member published by a leaderboard created WithEventChannel or WithEventStream.
+// ranks are 0 when the member is not in the leaderboard.
+type Event struct {
+	// ID : stream message id. empty for channel events.
+	ID          string    `json:"id,omitempty"`
+	Type        EventType `json:"type"`
+	Leaderboard string    `json:"leaderboard"`
+	Member      string    `json:"member"`
+	OldScore    float64   `json:"old_score"`
+	OldRank     int       `json:"old_rank"`
+	NewScore    float64   `json:"new_score"`
+	NewRank     int       `json:"new_rank"`
+	// Watch : crossed threshold of EventEntered and EventLeft, e.g. "top:100" or "score:5000".
+	Watch string    `json:"watch,omitempty"`
+	Time  time.Time `json:"time"`
+}
+
+// WithEventChannel : publish an Event for every change on the pub/sub channel "name:events".
+// each write costs a few more round trips to read the old and the new ranks.
+func WithEventChannel() Option {
+	return func(lb *Leaderboard) {
+		lb.events = channelEvents
+	}
+}
+
+// WithEventStream : append an Event for every change to the stream "name:events", trimmed to about maxLen events.
+// maxLen 0 keeps every event.
+func WithEventStream(maxLen int) Option {
+	return func(lb *Leaderboard) {
+		lb.events = streamEvents
+		lb.eventMaxLen = maxLen
+	}
+}
+
+// eventsKey : channel or stream of the events.
+func (lb *Leaderboard) eventsKey() string {
+	return lb.Name + ":events"
+}
+
+// snapshot : scores and ranks of members at some point, for the events. missing members are left out.
+type snapshot map[string]*RankScore
+
+// tracksChanges : writes must compare the scores and ranks from before and after, for the events or the watches.
+func (lb *Leaderboard) tracksChanges() bool {
+	return lb.events != noEvents || len(lb.watches) > 0
+}
+
+// snapshot : current scores and ranks of members. nil when the leaderboard does not track changes.
+func (lb *Leaderboard) snapshot(ctx context.Context, members ...string) (snapshot, error) {
+	if !lb.tracksChanges() || len(members) == 0 {
+		return nil, nil
+	}
+	if err := lb.decayErr(); err != nil {
+		return nil, err
+	}
+
+	scores, found, err := lb.backend.ZMScore(ctx, lb.Name, members...)
+	if err != nil {
+		return nil, err
+	}
+	ranks, err := lb.ranksFor(ctx, members, scores, found)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(snapshot, len(members))
+	for i, member := range members {
+		if found[i] {
+			res[member] = &RankScore{Member: member, score: lb.decode(scores[i]), rank: ranks[i]}
+		}
+	}
+	return res, nil
+}
+
+// publish : send an event for each of members, from the before to the after snapshot.
+func (lb *Leaderboard) publish(ctx context.Context, eventType EventType, before snapshot, after snapshot, members []string) error {
+	if lb.events == noEvents || len(members) == 0 {
+		return nil
+	}
+
+	events := make([]*Event, 0, len(members))
+	for _, member := range members {
+		event := &Event{Type: eventType, Member: member}
+		if old, ok := before[member]; ok {
+			event.OldScore, event.OldRank = old.score, old.rank
+		}
+		if cur, ok := after[member]; ok {
+			event.NewScore, event.NewRank = cur.score, cur.rank
+		}
+		events = append(events, event)
+	}
+	return lb.send(ctx, events)
+}
+
+// send : publish events on the channel or the stream of the leaderboard.
+func (lb *Leaderboard) send(ctx context.Context, events []*Event) error {
+	if lb.events == noEvents || len(events) == 0 {
+		return nil
+	}
+
+	now := lb.now().UTC()
+	messages := make([]string, 0, len(events))
+	for _, event := range events {
+		event.Leaderboard, event.Time = lb.Name, now
+		message, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		messages = append(messages, string(message))
+	}
+
+	if lb.events == streamEvents {
+		return lb.backend.XAdd(ctx, lb.eventsKey(), lb.eventMaxLen, messages...)
+	}
+	return lb.backend.Publish(ctx, lb.eventsKey(), messages...)
+}
+
+// Subscribe : Receive the events of a leaderboard created WithEventChannel until ctx is done.
+// events published while nobody listens are lost. use WithEventStream to read them later.
+func (lb *Leaderboard) Subscribe(ctx context.Context) (<-chan *Event, error) {
+	messages, err := lb.backend.Subscribe(ctx, lb.eventsKey())
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan *Event, cap(messages))
+	go func() {
+		defer close(events)
+		for message := range messages {
+			event := &Event{}
+			if err := json.Unmarshal([]byte(message), event); err != nil {
+				continue
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+// ReadEvents : Read up to count events of a leaderboard created WithEventStream after the event lastID.
+// lastID "0" reads from the oldest event and "$" only new ones. waits up to block when there is no event.
+func (lb *Leaderboard) ReadEvents(ctx context.Context, lastID string, count int, block time.Duration) ([]*Event, error) {
+	messages, err := lb.backend.XRead(ctx, lb.eventsKey(), lastID, count, block)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*Event, 0, len(messages))
+	for _, message := range messages {
+		event := &Event{}
+		if err := json.Unmarshal([]byte(message.Message), event); err != nil {
+			return nil, err
+		}
+		event.ID = message.ID
+		events = append(events, event)
+	}
+	return events, nil
+}
@@ -0,0 +1,93 @@
+package rank
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func testEventStream(t *testing.T, b Backend) {
+	ctx := context.Background()
+	lb := NewLeaderboardWithBackend(b, lbName, WithEventStream(100))
+	defer lb.Delete(ctx)
+	now := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+	lb.now = func() time.Time { return now }
+
+	lb.RankMember(ctx, "member_1", 50)
+	lb.RankMember(ctx, "member_2", 40)
+	lb.ChangeScoreFor(ctx, "member_2", 20)
+	lb.RankMembers(ctx, []*RankScore{NewRankScore("member_3", 10), NewRankScore("member_4", 5)})
+	lb.RemoveMember(ctx, "member_1")
+	lb.RemoveMembersInScoreRange(ctx, 0, 7)
+
+	events, err := lb.ReadEvents(ctx, "0", 0, 0)
+	if err != nil || len(events) != 7 {
+		t.Fatal("Leaderboard ReadEvents Err!", events, err)
+	}
+
+	// member_2 overtakes member_1.
+	if e := events[2]; e.Type != EventRanked || e.Member != "member_2" || e.OldScore != 40 || e.OldRank != 2 || e.NewScore != 60 || e.NewRank != 1 {
+		t.Error("Leaderboard ChangeScoreFor Event Err!", e)
+	}
+	if e := events[0]; e.OldRank != 0 || e.NewRank != 1 || e.Leaderboard != lbName || !e.Time.Equal(now) {
+		t.Error("Leaderboard RankMember Event Err!", e)
+	}
+	if e := events[5]; e.Type != EventRemoved || e.Member != "member_1" || e.OldRank != 2 || e.NewRank != 0 {
+		t.Error("Leaderboard RemoveMember Event Err!", e)
+	}
+	if e := events[6]; e.Type != EventRemoved || e.Member != "member_4" {
+		t.Error("Leaderboard RemoveMembersInScoreRange Event Err!", e)
+	}
+
+	// only the events after lastID, waiting for a new one.
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		lb.RankMember(ctx, "member_5", 1)
+	}()
+	if events, _ := lb.ReadEvents(ctx, events[6].ID, 10, time.Second); len(events) != 1 || events[0].Member != "member_5" {
+		t.Error("Leaderboard ReadEvents Err!", events)
+	}
+	// the stream goes with the leaderboard.
+	lb.Delete(ctx)
+	if events, err := lb.ReadEvents(ctx, "0", 0, 0); err != nil || len(events) != 0 {
+		t.Error("Leaderboard Delete Events Err!", events, err)
+	}
+}
+
+func testEventChannel(t *testing.T, b Backend) {
+	ctx, cancel := context.WithCancel(context.Background())
+	lb := NewLeaderboardWithBackend(b, lbName, WithEventChannel())
+	defer lb.Delete(context.Background())
+
+	events, err := lb.Subscribe(ctx)
+	if err != nil {
+		t.Fatal("Leaderboard Subscribe Err!", err)
+	}
+
+	lb.RankMember(ctx, "member_1", 50)
+	lb.RankMemberWithPolicy(ctx, "member_1", 40, PolicyMax)
+	lb.RankMemberWithPolicy(ctx, "member_1", 70, PolicyMax)
+
+	for _, expected := range []float64{50, 70} {
+		select {
+		case e := <-events:
+			if e.Member != "member_1" || e.NewScore != expected {
+				t.Error("Leaderboard Subscribe Err!", e)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Leaderboard Subscribe Err! timeout")
+		}
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestEvents(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	for _, b := range []Backend{backend, NewMemoryBackend()} {
+		testEventStream(t, b)
+		testEventChannel(t, b)
+	}
+}
@@ -0,0 +1,203 @@
+package rank
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// FriendScore : standing of a member among a group of friends.
+// the embedded RankScore holds the score and the global rank.
+type FriendScore struct {
+	RankScore
+	// FriendRank : rank among the friends, following the ranking mode of the leaderboard.
+	FriendRank int
+}
+
+func (m *FriendScore) String() string {
+	return fmt.Sprintf("%s friend_rank:%d", m.RankScore.String(), m.FriendRank)
+}
+
+// AddFriends : Add friends to the friend set stored at key.
+func (lb *Leaderboard) AddFriends(ctx context.Context, key string, friends ...string) error {
+	return lb.backend.SAdd(ctx, key, friends...)
+}
+
+// RemoveFriends : Remove friends from the friend set stored at key.
+func (lb *Leaderboard) RemoveFriends(ctx context.Context, key string, friends ...string) error {
+	return lb.backend.SRem(ctx, key, friends...)
+}
+
+// StoredFriends : Retrieve the friends in the friend set stored at key, for the Friends queries.
+func (lb *Leaderboard) StoredFriends(ctx context.Context, key string) ([]string, error) {
+	return lb.backend.SMembers(ctx, key)
+}
+
+// Friends : Retrieve member and friends ranked against each other. friends not in the leaderboard are left out.
+func (lb *Leaderboard) Friends(ctx context.Context, member string, friends []string) ([]*FriendScore, error) {
+	group := []string{member}
+	seen := map[string]bool{member: true}
+	for _, friend := range friends {
+		if !seen[friend] {
+			seen[friend] = true
+			group = append(group, friend)
+		}
+	}
+
+	if err := lb.decayErr(); err != nil {
+		return nil, err
+	}
+	scores, found, err := lb.backend.ZMScore(ctx, lb.Name, group...)
+	if err != nil {
+		return nil, err
+	}
+	return lb.rankFriends(ctx, group, scores, found)
+}
+
+// FriendsOf : Retrieve member and the friends of the friend set stored at key ranked against each other.
+// the backend intersects the set with the leaderboard, so friends not in the leaderboard are never loaded.
+func (lb *Leaderboard) FriendsOf(ctx context.Context, member string, key string) ([]*FriendScore, error) {
+	if err := lb.decayErr(); err != nil {
+		return nil, err
+	}
+	entries, err := lb.backend.ZInter(ctx, lb.Name, key)
+	if err != nil {
+		return nil, err
+	}
+
+	group := []string{member}
+	scores, found := []float64{0}, []bool{false}
+	for _, entry := range entries {
+		if entry.Member == member {
+			scores[0], found[0] = entry.Score, true
+			continue
+		}
+		group = append(group, entry.Member)
+		scores, found = append(scores, entry.Score), append(found, true)
+	}
+	if !found[0] {
+		if scores[0], found[0], err = lb.backend.ZScore(ctx, lb.Name, member); err != nil {
+			return nil, err
+		}
+	}
+	return lb.rankFriends(ctx, group, scores, found)
+}
+
+// rankFriends : standings of the members of group found in the leaderboard with scores, ranked against each other.
+func (lb *Leaderboard) rankFriends(ctx context.Context, group []string, scores []float64, found []bool) ([]*FriendScore, error) {
+	ranks, err := lb.ranksFor(ctx, group, scores, found)
+	if err != nil {
+		return nil, err
+	}
+
+	standings := make([]*FriendScore, 0, len(group))
+	for i, name := range group {
+		if found[i] {
+			standings = append(standings, &FriendScore{RankScore: RankScore{Member: name, score: lb.decode(scores[i]), rank: ranks[i]}})
+		}
+	}
+
+	// the global rank keeps the tie order of the ranking mode.
+	sort.SliceStable(standings, func(i, j int) bool {
+		if standings[i].score != standings[j].score {
+			return lb.better(standings[i].score, standings[j].score)
+		}
+		if standings[i].rank != standings[j].rank {
+			return standings[i].rank < standings[j].rank
+		}
+		return standings[i].Member < standings[j].Member
+	})
+	for i, standing := range standings {
+		tied := i > 0 && standing.score == standings[i-1].score
+		switch {
+		case tied && (lb.rankingMode == RankStandard || lb.rankingMode == RankDense):
+			standing.FriendRank = standings[i-1].FriendRank
+		case lb.rankingMode == RankDense && i > 0:
+			standing.FriendRank = standings[i-1].FriendRank + 1
+		default:
+			standing.FriendRank = i + 1
+		}
+	}
+
+	if lb.memberData && len(standings) > 0 {
+		members := make([]*RankScore, 0, len(standings))
+		for _, standing := range standings {
+			members = append(members, &standing.RankScore)
+		}
+		if err := lb.fillMemberData(ctx, members); err != nil {
+			return nil, err
+		}
+	}
+	return standings, nil
+}
+
+// FriendRankFor : Retrieve the standing of member among friends. returns ErrMemberNotFound when member is not in the leaderboard.
+func (lb *Leaderboard) FriendRankFor(ctx context.Context, member string, friends []string) (*FriendScore, error) {
+	standings, err := lb.Friends(ctx, member, friends)
+	if err != nil {
+		return nil, err
+	}
+	for _, standing := range standings {
+		if standing.Member == member {
+			return standing, nil
+		}
+	}
+	return nil, ErrMemberNotFound
+}
+
+// FriendsPage : Retrieve a page of member and friends ranked against each other.
+func (lb *Leaderboard) FriendsPage(ctx context.Context, member string, friends []string, currentPage int, pageSize int) ([]*FriendScore, error) {
+	if currentPage < 1 {
+		currentPage = 1
+	}
+	if pageSize < 1 {
+		pageSize = DEFAULT_PAGESIZE
+	}
+
+	standings, err := lb.Friends(ctx, member, friends)
+	if err != nil {
+		return nil, err
+	}
+
+	start := (currentPage - 1) * pageSize
+	if start > len(standings) {
+		start = len(standings)
+	}
+	end := start + pageSize
+	if end > len(standings) {
+		end = len(standings)
+	}
+	return standings[start:end], nil
+}
+
+// FriendsAroundMe : Retrieve a page of the friends around member.
+func (lb *Leaderboard) FriendsAroundMe(ctx context.Context, member string, friends []string, pageSize int) ([]*FriendScore, error) {
+	if pageSize < 1 {
+		pageSize = DEFAULT_PAGESIZE
+	}
+
+	standings, err := lb.Friends(ctx, member, friends)
+	if err != nil {
+		return nil, err
+	}
+
+	position := -1
+	for i, standing := range standings {
+		if standing.Member == member {
+			position = i
+		}
+	}
+	if position < 0 {
+		return []*FriendScore{}, ErrMemberNotFound
+	}
+
+	start := position - (pageSize / 2)
+	if start < 0 {
+		start = 0
+	}
+	end := start + pageSize
+	if end > len(standings) {
+		end = len(standings)
+	}
+	return standings[start:end], nil
+}
@@ -0,0 +1,85 @@
+package rank
+
+import (
+	"context"
+	"testing"
+)
+
+func testFriends(t *testing.T, b Backend) {
+	ctx := context.Background()
+	lb := NewLeaderboardWithBackend(b, lbName)
+	defer lb.Delete(ctx)
+	defer b.Del(ctx, lbName+"_friends")
+
+	for i, score := range []float64{100, 90, 80, 70, 60, 50, 40} {
+		lb.RankMember(ctx, "member_"+string(rune('a'+i)), score)
+	}
+	lb.RankMember(ctx, "member_h", 70)
+
+	// member_d's friends. member_x is not in the leaderboard.
+	lb.AddFriends(ctx, lbName+"_friends", "member_b", "member_h", "member_f", "member_x")
+	friends, err := lb.StoredFriends(ctx, lbName+"_friends")
+	if err != nil || len(friends) != 4 {
+		t.Fatal("Leaderboard StoredFriends Err!", friends, err)
+	}
+
+	standings, err := lb.Friends(ctx, "member_d", friends)
+	if err != nil || len(standings) != 4 {
+		t.Fatal("Leaderboard Friends Err!", standings, err)
+	}
+	expected := []struct {
+		member     string
+		friendRank int
+		rank       int
+	}{{"member_b", 1, 2}, {"member_d", 2, 4}, {"member_h", 2, 4}, {"member_f", 4, 7}}
+	for i, e := range expected {
+		if standings[i].Member != e.member || standings[i].FriendRank != e.friendRank || standings[i].GetRank() != e.rank {
+			t.Error("Leaderboard Friends Err!", standings[i], e)
+		}
+	}
+
+	stored, err := lb.FriendsOf(ctx, "member_d", lbName+"_friends")
+	if err != nil || len(stored) != 4 {
+		t.Fatal("Leaderboard FriendsOf Err!", stored, err)
+	}
+	for i, e := range expected {
+		if stored[i].Member != e.member || stored[i].FriendRank != e.friendRank || stored[i].GetRank() != e.rank {
+			t.Error("Leaderboard FriendsOf Err!", stored[i], e)
+		}
+	}
+	if stored, _ := lb.FriendsOf(ctx, "member_x", lbName+"_friends"); len(stored) != 3 {
+		t.Error("Leaderboard FriendsOf Err!", stored)
+	}
+	if stored, _ := lb.FriendsOf(ctx, "member_b", lbName+"_nobody"); len(stored) != 1 || stored[0].FriendRank != 1 {
+		t.Error("Leaderboard FriendsOf Err!", stored)
+	}
+
+	if me, _ := lb.FriendRankFor(ctx, "member_d", friends); me == nil || me.FriendRank != 2 || me.GetScore() != 70 {
+		t.Error("Leaderboard FriendRankFor Err!", me)
+	}
+	if page, _ := lb.FriendsPage(ctx, "member_d", friends, 2, 3); len(page) != 1 || page[0].Member != "member_f" {
+		t.Error("Leaderboard FriendsPage Err!", page)
+	}
+	if around, _ := lb.FriendsAroundMe(ctx, "member_f", friends, 2); len(around) != 2 || around[0].Member != "member_h" || around[1].Member != "member_f" {
+		t.Error("Leaderboard FriendsAroundMe Err!", around)
+	}
+	if _, err := lb.FriendsAroundMe(ctx, "member_x", friends, 2); err == nil {
+		t.Error("Leaderboard FriendsAroundMe Err!", err)
+	}
+
+	lb.RemoveFriends(ctx, lbName+"_friends", "member_b")
+	if friends, _ := lb.StoredFriends(ctx, lbName+"_friends"); len(friends) != 3 {
+		t.Error("Leaderboard RemoveFriends Err!", friends)
+	}
+
+	lb.rankingMode = RankDense
+	if standings, _ := lb.Friends(ctx, "member_d", []string{"member_b", "member_h", "member_f"}); standings[3].FriendRank != 3 {
+		t.Error("Leaderboard Friends Err!", standings)
+	}
+}
+
+func TestFriends(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	testFriends(t, backend)
+	testFriends(t, NewMemoryBackend())
+}
@@ -0,0 +1,755 @@
+package rank
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// NewPool : create a redis connection pool for the given address.
+func NewPool(addr string, options ...redis.DialOption) *redis.Pool {
+	return &redis.Pool{
+		MaxIdle:     10,
+		IdleTimeout: 240 * time.Second,
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", addr, options...)
+		},
+		TestOnBorrow: func(c redis.Conn, t time.Time) error {
+			if time.Since(t) < time.Minute {
+				return nil
+			}
+			_, err := c.Do("PING")
+			return err
+		},
+	}
+}
+
+// Leaderboard : leaderboard stored in a sorted set of a Backend.
+// a Leaderboard is safe for concurrent use as long as its backend is.
+type Leaderboard struct {
+	Name        string
+	backend     Backend
+	rankingMode RankingMode
+	order       SortOrder
+	scale       float64
+	memberData  bool
+	hooks       []hook
+	events      eventTransport
+	eventMaxLen int
+	watches     []*watch
+	activity    bool
+	memberTTL   time.Duration
+	decay       *decay
+	now         func() time.Time
+}
+
+// hook : called with the members whose score was written or who were removed.
+type hook func(ctx context.Context, members []string) error
+
+// Option : leaderboard option for NewLeaderboard.
+type Option func(*Leaderboard)
+
+// NewLeaderboard : create a leaderboard named lbName backed by a redis pool.
+func NewLeaderboard(pool *redis.Pool, lbName string, options ...Option) *Leaderboard {
+	return NewLeaderboardWithBackend(NewRedisBackend(pool), lbName, options...)
+}
+
+// NewLeaderboardWithBackend : create a leaderboard named lbName stored in backend.
+func NewLeaderboardWithBackend(backend Backend, lbName string, options ...Option) *Leaderboard {
+	lb := &Leaderboard{Name: lbName, backend: backend, now: time.Now}
+	for _, option := range options {
+		option(lb)
+	}
+	return lb
+}
+
+// RankMember :   Rank a member in the leaderboard.
+func (lb *Leaderboard) RankMember(ctx context.Context, member string, score float64) error {
+	value, err := lb.stored(score)
+	if err != nil {
+		return err
+	}
+	before, err := lb.snapshot(ctx, member)
+	if err != nil {
+		return err
+	}
+	if err := lb.submit(ctx, PolicyReplace, Entry{Member: member, Score: value}); err != nil {
+		return err
+	}
+	return lb.written(ctx, before, member)
+}
+
+// RankMembers : Rank an array of members in the leaderboard.
+func (lb *Leaderboard) RankMembers(ctx context.Context, membersAndScores []*RankScore) error {
+	entries := make([]Entry, 0, len(membersAndScores))
+	members := make([]string, 0, len(membersAndScores))
+	for _, memberScore := range membersAndScores {
+		value, err := lb.stored(memberScore.score)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, Entry{Member: memberScore.Member, Score: value})
+		members = append(members, memberScore.Member)
+	}
+	before, err := lb.snapshot(ctx, members...)
+	if err != nil {
+		return err
+	}
+	if err := lb.submit(ctx, PolicyReplace, entries...); err != nil {
+		return err
+	}
+	return lb.written(ctx, before, members...)
+}
+
+// RemoveMember : Remove a member from the leaderboard.
+func (lb *Leaderboard) RemoveMember(ctx context.Context, member string) error {
+	before, err := lb.snapshot(ctx, member)
+	if err != nil {
+		return err
+	}
+	if _, err := lb.backend.ZRem(ctx, lb.Name, member); err != nil {
+		return err
+	}
+	return lb.removed(ctx, before, member)
+}
+
+// TotalMembers : Retrieve the total number of members in the leaderboard.
+func (lb *Leaderboard) TotalMembers(ctx context.Context) (int, error) {
+	count, err := lb.backend.ZCard(ctx, lb.Name)
+	if err != nil {
+		return -1, err
+	}
+	return count, nil
+}
+
+// TotalPages : Retrieve the total number of pages in the leaderboard.
+func (lb *Leaderboard) TotalPages(ctx context.Context, pageSize int) int {
+	if pageSize < 1 {
+		pageSize = DEFAULT_PAGESIZE
+	}
+
+	totalMembers, _ := lb.TotalMembers(ctx)
+	return int(math.Ceil(float64(totalMembers) / float64(pageSize)))
+}
+
+// TotalMembersInScoreRange : Retrieve the total members in a given score range from the leaderboard.
+func (lb *Leaderboard) TotalMembersInScoreRange(ctx context.Context, minScore float64, maxScore float64) (int, error) {
+	if err := lb.decayErr(); err != nil {
+		return -1, err
+	}
+	count, err := lb.backend.ZCount(ctx, lb.Name, lb.bound(minScore, false), lb.bound(maxScore, false))
+	if err != nil {
+		return -1, err
+	}
+	return count, nil
+}
+
+// ChangeScoreFor : Change the score for a member in the leaderboard by a score delta which can be positive or negative.
+func (lb *Leaderboard) ChangeScoreFor(ctx context.Context, member string, delta float64) error {
+	value, err := lb.stored(delta)
+	if err != nil {
+		return err
+	}
+	before, err := lb.snapshot(ctx, member)
+	if err != nil {
+		return err
+	}
+	if err := lb.submit(ctx, PolicyIncrement, Entry{Member: member, Score: value}); err != nil {
+		return err
+	}
+	return lb.written(ctx, before, member)
+}
+
+// CheckMember : Check to see if a member exists in the leaderboard.
+func (lb *Leaderboard) CheckMember(ctx context.Context, member string) (bool, error) {
+	_, found, err := lb.backend.ZScore(ctx, lb.Name, member)
+	if err != nil {
+		return false, err
+	}
+	return found, nil
+}
+
+// RankMemberEx :   Rank a member in the leaderboard and return the new rank.
+// under RankStandard the score is set and the rank computed atomically, so concurrent writers never see an
+// inconsistent rank. other ranking modes compute the rank in a read after the write, which may see later writes.
+func (lb *Leaderboard) RankMemberEx(ctx context.Context, member string, score float64) (int, error) {
+	res, err := lb.RankMemberWithPolicy(ctx, member, score, PolicyReplace)
+	if err != nil {
+		return 0, err
+	}
+	return res.rank, nil
+}
+
+// scoreFor : stored score of member. ErrMemberNotFound for a non-existent member.
+func (lb *Leaderboard) scoreFor(ctx context.Context, member string) (float64, error) {
+	score, found, err := lb.backend.ZScore(ctx, lb.Name, member)
+	if err != nil {
+		return -1, err
+	}
+	if !found {
+		return -1, ErrMemberNotFound
+	}
+	return score, nil
+}
+
+// ScoreFor : Retrieve the score for a member in the leaderboard.
+func (lb *Leaderboard) ScoreFor(ctx context.Context, member string) (float64, error) {
+	if err := lb.decayErr(); err != nil {
+		return -1, err
+	}
+	score, err := lb.scoreFor(ctx, member)
+	if err != nil {
+		return -1, err
+	}
+	return lb.decode(score), nil
+}
+
+// RankFor : Retrieve the rank for a member in the leaderboard.
+func (lb *Leaderboard) RankFor(ctx context.Context, member string) (int, error) {
+	if err := lb.decayErr(); err != nil {
+		return -1, err
+	}
+	score, err := lb.scoreFor(ctx, member)
+	if err != nil {
+		return -1, err
+	}
+
+	return lb.rankFor(ctx, member, score)
+}
+
+// ScoreAndRankFor : Retrieve the score and rank for a member in the leaderboard.
+func (lb *Leaderboard) ScoreAndRankFor(ctx context.Context, member string) (*RankScore, error) {
+	if err := lb.decayErr(); err != nil {
+		return nil, err
+	}
+	score, err := lb.scoreFor(ctx, member)
+	if err != nil {
+		return nil, err
+	}
+
+	rank, err := lb.rankFor(ctx, member, score)
+	if err != nil {
+		return nil, err
+	}
+
+	memberScore := &RankScore{Member: member, score: lb.decode(score), rank: rank}
+	if lb.memberData {
+		if err := lb.fillMemberData(ctx, []*RankScore{memberScore}); err != nil {
+			return nil, err
+		}
+	}
+	return memberScore, nil
+}
+
+// RemoveMembersInScoreRange : Remove members from the leaderboard in a given score range.
+func (lb *Leaderboard) RemoveMembersInScoreRange(ctx context.Context, minScore float64, maxScore float64) error {
+	if err := lb.decayErr(); err != nil {
+		return err
+	}
+	min := lb.bound(minScore, false)
+	max := lb.bound(maxScore, false)
+
+	if lb.tracksRemovals() {
+		entries, err := lb.byScore(ctx, min, max)
+		if err != nil {
+			return err
+		}
+		return lb.removeEntries(ctx, entries)
+	}
+
+	_, err := lb.backend.ZRemRangeByScore(ctx, lb.Name, min, max)
+	return err
+}
+
+// tracksRemovals : removals must know the removed members, for the member hashes and time sets, the hooks,
+// the events or the watches.
+func (lb *Leaderboard) tracksRemovals() bool {
+	return lb.rankingMode == RankEarliestFirst || lb.memberData || lb.tracksTimes() || len(lb.hooks) > 0 || lb.tracksChanges()
+}
+
+// tracksTimes : writes record the activity or the expiry of the members.
+func (lb *Leaderboard) tracksTimes() bool {
+	return lb.activity || lb.memberTTL > 0
+}
+
+// written : members got a new score. before is their snapshot from before the write.
+func (lb *Leaderboard) written(ctx context.Context, before snapshot, members ...string) error {
+	if err := lb.record(ctx, members...); err != nil {
+		return err
+	}
+	if err := lb.runHooks(ctx, members); err != nil {
+		return err
+	}
+	after, err := lb.snapshot(ctx, members...)
+	if err != nil {
+		return err
+	}
+	if err := lb.publish(ctx, EventRanked, before, after, members); err != nil {
+		return err
+	}
+	return lb.watch(ctx, before, after, members)
+}
+
+// removed : members left the leaderboard. before is their snapshot from before the removal.
+func (lb *Leaderboard) removed(ctx context.Context, before snapshot, members ...string) error {
+	if err := lb.forget(ctx, members...); err != nil {
+		return err
+	}
+	if err := lb.runHooks(ctx, members); err != nil {
+		return err
+	}
+	if err := lb.publish(ctx, EventRemoved, before, nil, members); err != nil {
+		return err
+	}
+	return lb.watch(ctx, before, nil, members)
+}
+
+func (lb *Leaderboard) runHooks(ctx context.Context, members []string) error {
+	if len(members) == 0 {
+		return nil
+	}
+	for _, h := range lb.hooks {
+		if err := h(ctx, members); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forget : drop the hash fields of removed members.
+func (lb *Leaderboard) forget(ctx context.Context, members ...string) error {
+	if len(members) == 0 {
+		return nil
+	}
+	if lb.rankingMode == RankEarliestFirst {
+		if err := lb.backend.HDel(ctx, lb.timestampKey(), members...); err != nil {
+			return err
+		}
+	}
+	if lb.memberData {
+		if err := lb.backend.HDel(ctx, lb.memberDataKey(), members...); err != nil {
+			return err
+		}
+	}
+	if lb.tracksTimes() {
+		return lb.forgetTimes(ctx, members...)
+	}
+	return nil
+}
+
+// removeEntries : remove entries with their hash fields.
+func (lb *Leaderboard) removeEntries(ctx context.Context, entries []Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	members := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		members = append(members, entry.Member)
+	}
+	before, err := lb.snapshot(ctx, members...)
+	if err != nil {
+		return err
+	}
+	if _, err := lb.backend.ZRem(ctx, lb.Name, members...); err != nil {
+		return err
+	}
+	return lb.removed(ctx, before, members...)
+}
+
+// RemoveMembersOutsideRank : Remove members from the leaderboard outside a given rank.
+func (lb *Leaderboard) RemoveMembersOutsideRank(ctx context.Context, rank int) (int, error) {
+	if lb.tracksRemovals() {
+		// ZREMRANGEBYRANK does not report the removed members.
+		entries, err := lb.rangeByPosition(ctx, rank, -1)
+		if err != nil {
+			return -1, err
+		}
+		if err := lb.removeEntries(ctx, entries); err != nil {
+			return -1, err
+		}
+		return len(entries), nil
+	}
+
+	count, err := lb.removeWorse(ctx, rank)
+	if err != nil {
+		return -1, err
+	}
+	return count, nil
+}
+
+// PercentileFor : Retrieve the percentile for a member in the leaderboard.
+// @param member [String] Member name.
+// @return the percentile for a member in the leaderboard. Return ErrMemberNotFound for a non-existent member.
+func (lb *Leaderboard) PercentileFor(ctx context.Context, member string) (int, error) {
+	if ok, err := lb.CheckMember(ctx, member); err != nil {
+		return -1, err
+	} else if !ok {
+		return -1, ErrMemberNotFound
+	}
+
+	count, err := lb.backend.ZCard(ctx, lb.Name)
+	if err != nil {
+		return -1, err
+	}
+
+	// members ranked below member.
+	var below int
+	switch lb.rankingMode {
+	case RankOrdinal, RankEarliestFirst:
+		position, err := lb.positionFor(ctx, member)
+		if err != nil {
+			return -1, err
+		}
+		below = count - position - 1
+	default:
+		score, err := lb.scoreFor(ctx, member)
+		if err != nil {
+			return -1, err
+		}
+		min, max := lb.worseRange(score)
+		if below, err = lb.backend.ZCount(ctx, lb.Name, min, max); err != nil {
+			return -1, err
+		}
+	}
+
+	return int(math.Ceil(float64(below) / float64(count) * 100.0)), nil
+}
+
+// ScoreForPercentile : Calculate the score for a given percentile value in the leaderboard.
+// returns ErrInvalidPercentile outside 0..100 and ErrLeaderboardEmpty without members.
+func (lb *Leaderboard) ScoreForPercentile(ctx context.Context, percentile int) (float64, error) {
+	if percentile < 0 || percentile > 100 {
+		return -1, ErrInvalidPercentile
+	}
+	if err := lb.decayErr(); err != nil {
+		return -1, err
+	}
+
+	totalMembers, err := lb.TotalMembers(ctx)
+	if err != nil {
+		return -1, err
+	}
+	if totalMembers < 1 {
+		return -1, ErrLeaderboardEmpty
+	}
+
+	index := float64((float64(totalMembers) - 1.0) * (float64(percentile) / 100.0))
+
+	values, err := lb.byIndex(ctx, int(math.Floor(index)), int(math.Ceil(index)))
+	if err != nil {
+		return -1, err
+	}
+	lowScore := lb.decode(values[0].Score)
+
+	if index == math.Floor(index) || len(values) < 2 {
+		return lowScore, nil
+	}
+
+	interpolateFraction := index - math.Floor(index)
+	hiScore := lb.decode(values[1].Score)
+
+	return lowScore + interpolateFraction*(hiScore-lowScore), nil
+}
+
+// PageFor : Determine the page where a member falls in the leaderboard.
+func (lb *Leaderboard) PageFor(ctx context.Context, member string, pageSize int) (int, error) {
+	if pageSize < 1 {
+		pageSize = DEFAULT_PAGESIZE
+	}
+
+	rank, err := lb.RankFor(ctx, member)
+	if err != nil {
+		return -1, err
+	}
+
+	return int(math.Ceil(float64(rank) / float64(pageSize))), nil
+}
+
+// RankedInList : Retrieve a page of leaders from the leaderboard for a given list of members.
+// every member gets an entry in the order given. GetErr of the members not in the leaderboard is ErrMemberNotFound.
+func (lb *Leaderboard) RankedInList(ctx context.Context, members []string) ([]*RankScore, error) {
+	ranksForMembers := make([]*RankScore, 0, len(members))
+	if len(members) == 0 {
+		return ranksForMembers, nil
+	}
+	if err := lb.decayErr(); err != nil {
+		return ranksForMembers, err
+	}
+
+	// Get Score and Rank of all the members at once
+	scores, found, err := lb.backend.ZMScore(ctx, lb.Name, members...)
+	if err != nil {
+		return ranksForMembers, err
+	}
+	ranks, err := lb.ranksFor(ctx, members, scores, found)
+	if err != nil {
+		return ranksForMembers, err
+	}
+
+	for i, member := range members {
+		memberScore := &RankScore{Member: member}
+		if found[i] {
+			memberScore.score, memberScore.rank = lb.decode(scores[i]), ranks[i]
+		} else {
+			memberScore.err = ErrMemberNotFound
+		}
+		ranksForMembers = append(ranksForMembers, memberScore)
+	}
+
+	if lb.memberData {
+		if err := lb.fillMemberData(ctx, ranksForMembers); err != nil {
+			return ranksForMembers, err
+		}
+	}
+
+	return ranksForMembers, nil
+}
+
+// rankedEntries : RankScores for entries that follow each other in the leaderboard order.
+// position is the zero based position of the first entry, or -1 when the entries start with a whole tie.
+// ranks are counted along the entries, so a page costs the same round trips whatever its size.
+func (lb *Leaderboard) rankedEntries(ctx context.Context, entries []Entry, position int, err error) ([]*RankScore, error) {
+	if err == nil {
+		err = lb.decayErr()
+	}
+	if err != nil {
+		return []*RankScore{}, err
+	}
+
+	ranksForMembers := make([]*RankScore, 0, len(entries))
+	if len(entries) == 0 {
+		return ranksForMembers, nil
+	}
+
+	// the members above the first entry.
+	better := position
+	min, max := lb.betterRange(entries[0].Score)
+	switch {
+	case lb.rankingMode == RankDense:
+		better, err = lb.backend.ZCountDistinct(ctx, lb.Name, min, max)
+	case lb.rankingMode == RankStandard || position < 0:
+		better, err = lb.backend.ZCount(ctx, lb.Name, min, max)
+		if position < 0 {
+			position = better
+		}
+	}
+	if err != nil {
+		return []*RankScore{}, err
+	}
+
+	rank := better + 1
+	for i, entry := range entries {
+		switch lb.rankingMode {
+		case RankStandard:
+			if i > 0 && entry.Score != entries[i-1].Score {
+				rank = position + i + 1
+			}
+		case RankDense:
+			if i > 0 && entry.Score != entries[i-1].Score {
+				rank++
+			}
+		default:
+			rank = position + i + 1
+		}
+		ranksForMembers = append(ranksForMembers, &RankScore{Member: entry.Member, score: lb.decode(entry.Score), rank: rank})
+	}
+
+	if lb.memberData {
+		if err := lb.fillMemberData(ctx, ranksForMembers); err != nil {
+			return []*RankScore{}, err
+		}
+	}
+
+	return ranksForMembers, nil
+}
+
+// Members : Retrieve a page of Members from the leaderboard.
+func (lb *Leaderboard) Members(ctx context.Context, currentPage int, pageSize int) ([]*RankScore, error) {
+	if currentPage < 1 {
+		currentPage = 1
+	}
+	if pageSize < 1 {
+		pageSize = DEFAULT_PAGESIZE
+	}
+
+	if totalPage := lb.TotalPages(ctx, pageSize); currentPage > totalPage {
+		currentPage = totalPage
+	}
+
+	indexForRedis := currentPage - 1
+
+	startingOffset := (indexForRedis * pageSize)
+	if startingOffset < 0 {
+		startingOffset = 0
+	}
+
+	endingOffset := (startingOffset + pageSize) - 1
+
+	entries, err := lb.rangeByPosition(ctx, startingOffset, endingOffset)
+	return lb.rankedEntries(ctx, entries, startingOffset, err)
+}
+
+// AllMembers : Retrieve all Members from the leaderboard.
+func (lb *Leaderboard) AllMembers(ctx context.Context) ([]*RankScore, error) {
+	entries, err := lb.rangeByPosition(ctx, 0, -1)
+	return lb.rankedEntries(ctx, entries, 0, err)
+}
+
+// MembersFromScoreRange : Retrieve members from the leaderboard within a given score range.
+// returns ErrInvalidRange when maximumScore is below minimumScore.
+func (lb *Leaderboard) MembersFromScoreRange(ctx context.Context, minimumScore float64, maximumScore float64) ([]*RankScore, error) {
+	if maximumScore < minimumScore {
+		return []*RankScore{}, ErrInvalidRange
+	}
+	if err := lb.decayErr(); err != nil {
+		return []*RankScore{}, err
+	}
+
+	startScore := lb.bound(minimumScore, false)
+	endScore := lb.bound(maximumScore, false)
+
+	entries, err := lb.byScore(ctx, startScore, endScore)
+	if err == nil {
+		entries, err = lb.sortTies(ctx, entries)
+	}
+	return lb.rankedEntries(ctx, entries, -1, err)
+}
+
+// MembersFromRankRange : Retrieve members from the leaderboard within a given rank range.
+// returns ErrInvalidRange when endingRank is before startingRank.
+func (lb *Leaderboard) MembersFromRankRange(ctx context.Context, startingRank int, endingRank int) ([]*RankScore, error) {
+	startingRank = startingRank - 1
+	if startingRank < 0 {
+		startingRank = 0
+	}
+	endingRank = endingRank - 1
+	if endingRank < startingRank {
+		return []*RankScore{}, ErrInvalidRange
+	}
+
+	totalMembers, _ := lb.TotalMembers(ctx)
+	if totalMembers == 0 {
+		return []*RankScore{}, nil
+	}
+	if endingRank > totalMembers {
+		endingRank = totalMembers - 1
+	}
+
+	entries, err := lb.rangeByPosition(ctx, startingRank, endingRank)
+	return lb.rankedEntries(ctx, entries, startingRank, err)
+}
+
+// Top : Retrieve members from the leaderboard within a range from 1 to the number given.
+func (lb *Leaderboard) Top(ctx context.Context, number int) ([]*RankScore, error) {
+	return lb.MembersFromRankRange(ctx, 1, number)
+}
+
+// MemberAt : Retrieve a member at the specified index from the leaderboard.
+// returns ErrLeaderboardEmpty without members and ErrInvalidRange for a position outside 1..TotalMembers.
+func (lb *Leaderboard) MemberAt(ctx context.Context, position int) (*RankScore, error) {
+	if position < 1 {
+		return nil, ErrInvalidRange
+	}
+	members, err := lb.MembersFromRankRange(ctx, position, position)
+	if err != nil {
+		return nil, err
+	}
+	if len(members) >= 1 {
+		return members[0], nil
+	}
+
+	totalMembers, err := lb.TotalMembers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if totalMembers == 0 {
+		return nil, ErrLeaderboardEmpty
+	}
+	return nil, ErrInvalidRange
+}
+
+// AroundMe : Retrieve a page of leaders from the leaderboard around a given member.
+func (lb *Leaderboard) AroundMe(ctx context.Context, member string, pageSize int) ([]*RankScore, error) {
+	if pageSize < 1 {
+		pageSize = DEFAULT_PAGESIZE
+	}
+
+	rank, err := lb.positionFor(ctx, member)
+	if err != nil {
+		return []*RankScore{}, err
+	}
+
+	startingOffset := rank - (pageSize / 2)
+	if startingOffset < 0 {
+		startingOffset = 0
+	}
+	endingOffset := (startingOffset + pageSize) - 1
+
+	entries, err := lb.rangeByPosition(ctx, startingOffset, endingOffset)
+	return lb.rankedEntries(ctx, entries, startingOffset, err)
+}
+
+// keys : every key the leaderboard is stored in.
+func (lb *Leaderboard) keys() []string {
+	keys := []string{lb.Name, lb.timestampKey(), lb.memberDataKey(), lb.activityKey(), lb.expiryKey()}
+	if lb.events == streamEvents {
+		keys = append(keys, lb.eventsKey())
+	}
+	for _, w := range lb.watches {
+		if w.top > 0 {
+			keys = append(keys, lb.watchKey(w))
+		}
+	}
+	return keys
+}
+
+// internalSuffixes : keys kept by a leaderboard next to its sorted set.
+var internalSuffixes = []string{":data", ":seasons", ":ts", ":events", ":active", ":expires", ":team_of"}
+
+// internalNames : keys of the top watches and of the archived seasons.
+var internalNames = regexp.MustCompile(`:top:\d+$|:season:`)
+
+// ValidName : check a leaderboard name given by a client, e.g. of a request to a server.
+// returns ErrInvalidName for an empty name and for the keys a leaderboard keeps next to its sorted set,
+// e.g. "scores:data" or "scores:top:10", so that clients can not read or overwrite them as leaderboards.
+func ValidName(name string) error {
+	if name == "" {
+		return fmt.Errorf("%w: empty name", ErrInvalidName)
+	}
+	for _, suffix := range internalSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return fmt.Errorf("%w: %q is an internal key", ErrInvalidName, name)
+		}
+	}
+	if internalNames.MatchString(name) {
+		return fmt.Errorf("%w: %q is an internal key", ErrInvalidName, name)
+	}
+	return nil
+}
+
+// Delete : Delete the leaderboard.
+func (lb *Leaderboard) Delete(ctx context.Context) error {
+	for _, key := range lb.keys() {
+		if err := lb.backend.Del(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExpireAt : Delete the leaderboard automatically at t.
+// only keys existing at the time of the call are expired.
+func (lb *Leaderboard) ExpireAt(ctx context.Context, t time.Time) error {
+	for _, key := range lb.keys() {
+		if err := lb.backend.ExpireAt(ctx, key, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,255 @@
+package rank
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+func TestLeaderboardConcurrent(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	ctx := context.Background()
+	lb := NewLeaderboardWithBackend(backend, lbName)
+	defer lb.Delete(ctx)
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := lb.RankMember(ctx, "member_"+strconv.Itoa(i), float64(i)); err != nil {
+				t.Error("Leaderboard RankMember Err!", err)
+			}
+			if _, err := lb.RankFor(ctx, "member_"+strconv.Itoa(i)); err != nil {
+				t.Error("Leaderboard RankFor Err!", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if totalMembers, _ := lb.TotalMembers(ctx); totalMembers != 50 {
+		t.Error("Leaderboard TotalMembers Err!", totalMembers)
+	}
+	if rank, _ := lb.RankFor(ctx, "member_50"); rank != 1 {
+		t.Error("Leaderboard RankFor Err!", rank)
+	}
+	if members, _ := lb.Top(ctx, 3); len(members) != 3 || members[2].Member != "member_48" {
+		t.Error("Leaderboard Top Err!", members)
+	}
+}
+
+func TestLeaderboardSeparateBoards(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	ctx := context.Background()
+	lb1 := NewLeaderboardWithBackend(backend, lbName+"_1")
+	lb2 := NewLeaderboardWithBackend(backend, lbName+"_2")
+	defer lb1.Delete(ctx)
+	defer lb2.Delete(ctx)
+
+	lb1.RankMember(ctx, "member_1", 10)
+	lb2.RankMember(ctx, "member_1", 20)
+	lb2.RankMember(ctx, "member_2", 30)
+
+	if score, _ := lb1.ScoreFor(ctx, "member_1"); score != 10 {
+		t.Error("Leaderboard ScoreFor Err!", score)
+	}
+	if rank, _ := lb2.RankFor(ctx, "member_1"); rank != 2 {
+		t.Error("Leaderboard RankFor Err!", rank)
+	}
+}
+
+func TestLeaderboardContext(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	lb := NewLeaderboardWithBackend(backend, lbName)
+	defer lb.Delete(context.Background())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := lb.RankMember(ctx, "member_1", 10); err != context.Canceled {
+		t.Error("Leaderboard RankMember Err!", err)
+	}
+	if _, err := lb.ScoreAndRankFor(ctx, "member_1"); err != context.Canceled {
+		t.Error("Leaderboard ScoreAndRankFor Err!", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+
+	if _, err := lb.MembersFromRankRange(ctx, 1, 10); err != context.DeadlineExceeded {
+		t.Error("Leaderboard MembersFromRankRange Err!", err)
+	}
+	if members, _ := lb.Members(context.Background(), 1, 10); len(members) != 0 {
+		t.Error("Leaderboard Members Err!", members)
+	}
+}
+
+func TestLeaderboardRankMemberExConcurrent(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	ctx := context.Background()
+	lb := NewLeaderboardWithBackend(backend, lbName)
+	defer lb.Delete(ctx)
+
+	lb.RankMember(ctx, "member_1", 100)
+	lb.RankMember(ctx, "member_2", 50)
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			rank, err := lb.RankMemberEx(ctx, "member_3", float64(i*10))
+			if err != nil {
+				t.Error("Leaderboard RankMemberEx Err!", err)
+				return
+			}
+
+			expected := 3
+			if i*10 >= 100 {
+				expected = 1
+			} else if i*10 >= 50 {
+				expected = 2
+			}
+			if rank != expected {
+				t.Error("Leaderboard RankMemberEx Err!", i*10, rank)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+// roundTripConn : redis connection counting the requests sent to the server.
+type roundTripConn struct {
+	redis.Conn
+	roundTrips *int64
+}
+
+func (c roundTripConn) Do(cmd string, args ...interface{}) (interface{}, error) {
+	if cmd != "" {
+		atomic.AddInt64(c.roundTrips, 1)
+	}
+	return c.Conn.Do(cmd, args...)
+}
+
+func (c roundTripConn) DoContext(ctx context.Context, cmd string, args ...interface{}) (interface{}, error) {
+	atomic.AddInt64(c.roundTrips, 1)
+	return c.Conn.(redis.ConnWithContext).DoContext(ctx, cmd, args...)
+}
+
+func (c roundTripConn) ReceiveContext(ctx context.Context) (interface{}, error) {
+	return c.Conn.(redis.ConnWithContext).ReceiveContext(ctx)
+}
+
+// roundTripPool : pool of the test redis counting its round trips.
+func roundTripPool() (*redis.Pool, *int64) {
+	roundTrips := new(int64)
+	dial := backend.(*redisBackend).pool.Dial
+	pool := &redis.Pool{
+		MaxIdle: 1,
+		Dial: func() (redis.Conn, error) {
+			c, err := dial()
+			if err != nil {
+				return nil, err
+			}
+			return roundTripConn{Conn: c, roundTrips: roundTrips}, nil
+		},
+	}
+	return pool, roundTrips
+}
+
+// roundTripLeaderboard : leaderboard on the test redis counting its round trips, filled with count members.
+func roundTripLeaderboard(tb testing.TB, count int, options ...Option) (*Leaderboard, *int64) {
+	ctx := context.Background()
+	pool, roundTrips := roundTripPool()
+	lb := NewLeaderboard(pool, lbName, options...)
+	lb.Delete(ctx)
+	membersAndScores := make([]*RankScore, 0, count)
+	for i := 1; i <= count; i++ {
+		membersAndScores = append(membersAndScores, NewRankScore("member_"+strconv.Itoa(i), float64(i%50)))
+	}
+	if err := lb.RankMembers(ctx, membersAndScores); err != nil {
+		tb.Fatal("Leaderboard RankMembers Err!", err)
+	}
+	return lb, roundTrips
+}
+
+func TestLeaderboardRoundTrips(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	ctx := context.Background()
+	lb, roundTrips := roundTripLeaderboard(t, 500, WithMemberData())
+	defer lb.Delete(ctx)
+
+	for _, mode := range []RankingMode{RankStandard, RankDense, RankOrdinal, RankEarliestFirst} {
+		lb.rankingMode = mode
+		if mode == RankEarliestFirst {
+			// submission times order some of the ties of member_1.
+			lb.RankMember(ctx, "member_101", 1)
+			lb.RankMember(ctx, "member_51", 1)
+		}
+		// the first call of a mode may load its script.
+		lb.Members(ctx, 1, 1)
+
+		var trips []int64
+		for _, pageSize := range []int{1, 25, 100} {
+			atomic.StoreInt64(roundTrips, 0)
+			if members, err := lb.Members(ctx, 2, pageSize); err != nil || len(members) != pageSize {
+				t.Fatal("Leaderboard Members Err!", mode, len(members), err)
+			}
+			trips = append(trips, atomic.LoadInt64(roundTrips))
+		}
+		if trips[0] != trips[1] || trips[1] != trips[2] {
+			t.Error("Leaderboard Members round trips Err!", mode, trips)
+		}
+
+		members := make([]string, 0, 100)
+		for i := 1; i <= 100; i++ {
+			members = append(members, "member_"+strconv.Itoa(i))
+		}
+		// earliest first also reads the ties and their submission times.
+		limit := int64(3)
+		if mode == RankEarliestFirst {
+			limit = 5
+		}
+		atomic.StoreInt64(roundTrips, 0)
+		ranked, _ := lb.RankedInList(ctx, members)
+		if trips := atomic.LoadInt64(roundTrips); trips > limit {
+			t.Error("Leaderboard RankedInList round trips Err!", mode, trips)
+		}
+
+		// bulk ranks agree with the ranks of single lookups.
+		for _, member := range ranked[:10] {
+			if rank, _ := lb.RankFor(ctx, member.Member); rank != member.rank {
+				t.Error("Leaderboard RankedInList Err!", mode, member, rank)
+			}
+		}
+		page, _ := lb.Members(ctx, 3, 10)
+		for _, member := range page {
+			if rank, _ := lb.RankFor(ctx, member.Member); rank != member.rank {
+				t.Error("Leaderboard Members Err!", mode, member, rank)
+			}
+		}
+	}
+}
+
+func BenchmarkLeaderboardMembers(b *testing.B) {
+	ctx := context.Background()
+	lb, roundTrips := roundTripLeaderboard(b, 10000)
+	defer lb.Delete(ctx)
+
+	for _, pageSize := range []int{25, 100, 1000} {
+		b.Run("page"+strconv.Itoa(pageSize), func(b *testing.B) {
+			atomic.StoreInt64(roundTrips, 0)
+			for i := 0; i < b.N; i++ {
+				if _, err := lb.Members(ctx, 3, pageSize); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(atomic.LoadInt64(roundTrips))/float64(b.N), "roundtrips/op")
+		})
+	}
+}
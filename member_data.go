@@ -0,0 +1,71 @@
+package rank
+
+import (
+	"context"
+)
+
+// WithMemberData : keep member data (display name, avatar, ...) alongside the scores.
+// query results carry the data and removals clean it up.
+func WithMemberData() Option {
+	return func(lb *Leaderboard) {
+		lb.memberData = true
+	}
+}
+
+// memberDataKey : hash of member -> member data.
+func (lb *Leaderboard) memberDataKey() string {
+	return lb.Name + ":data"
+}
+
+// RankMemberWithData : Rank a member in the leaderboard with member data.
+func (lb *Leaderboard) RankMemberWithData(ctx context.Context, member string, score float64, data string) error {
+	if err := lb.RankMember(ctx, member, score); err != nil {
+		return err
+	}
+	return lb.UpdateMemberData(ctx, member, data)
+}
+
+// MemberDataFor : Retrieve the member data for a member in the leaderboard.
+func (lb *Leaderboard) MemberDataFor(ctx context.Context, member string) (string, error) {
+	values, err := lb.backend.HMGet(ctx, lb.memberDataKey(), member)
+	if err != nil {
+		return "", err
+	}
+	data, ok := values[member]
+	if !ok {
+		return "", ErrMemberNotFound
+	}
+	return data, nil
+}
+
+// UpdateMemberData : Update the member data for a member in the leaderboard.
+func (lb *Leaderboard) UpdateMemberData(ctx context.Context, member string, data string) error {
+	return lb.backend.HSet(ctx, lb.memberDataKey(), map[string]string{member: data})
+}
+
+// UpdateMembersData : Update the member data of several members, member -> data, in one step.
+func (lb *Leaderboard) UpdateMembersData(ctx context.Context, data map[string]string) error {
+	return lb.backend.HSet(ctx, lb.memberDataKey(), data)
+}
+
+// RemoveMemberData : Remove the member data for a member in the leaderboard.
+func (lb *Leaderboard) RemoveMemberData(ctx context.Context, member string) error {
+	return lb.backend.HDel(ctx, lb.memberDataKey(), member)
+}
+
+// fillMemberData : set the member data of members in one lookup. members without data keep "".
+func (lb *Leaderboard) fillMemberData(ctx context.Context, members []*RankScore) error {
+	names := make([]string, 0, len(members))
+	for _, member := range members {
+		names = append(names, member.Member)
+	}
+
+	values, err := lb.backend.HMGet(ctx, lb.memberDataKey(), names...)
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		member.data = values[member.Member]
+	}
+	return nil
+}
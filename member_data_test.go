@@ -0,0 +1,91 @@
+package rank
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func testMemberData(t *testing.T, b Backend) {
+	ctx := context.Background()
+	lb := NewLeaderboardWithBackend(b, lbName, WithMemberData())
+	defer lb.Delete(ctx)
+
+	lb.RankMemberWithData(ctx, "member_1", 50, `{"name":"Alice","country":"KR"}`)
+	lb.RankMemberWithData(ctx, "member_2", 40, `{"name":"Bob","country":"US"}`)
+	lb.RankMemberWithData(ctx, "member_3", 30, `{"name":"Carol","country":"JP"}`)
+	lb.RankMember(ctx, "member_4", 20)
+
+	if data, err := lb.MemberDataFor(ctx, "member_2"); err != nil || data != `{"name":"Bob","country":"US"}` {
+		t.Error("Leaderboard MemberDataFor Err!", data, err)
+	}
+	if _, err := lb.MemberDataFor(ctx, "member_4"); err == nil {
+		t.Error("Leaderboard MemberDataFor Err!", err)
+	}
+
+	if members, _ := lb.Top(ctx, 4); len(members) != 4 ||
+		members[0].GetData() != `{"name":"Alice","country":"KR"}` || members[3].GetData() != "" {
+		t.Error("Leaderboard Top Err!", members)
+	}
+	if members, _ := lb.AroundMe(ctx, "member_3", 1); len(members) != 1 || members[0].GetData() != `{"name":"Carol","country":"JP"}` {
+		t.Error("Leaderboard AroundMe Err!", members)
+	}
+
+	lb.UpdateMemberData(ctx, "member_1", `{"name":"Alice2"}`)
+	if member, _ := lb.ScoreAndRankFor(ctx, "member_1"); member.GetData() != `{"name":"Alice2"}` {
+		t.Error("Leaderboard UpdateMemberData Err!", member)
+	}
+
+	lb.UpdateMembersData(ctx, map[string]string{"member_2": `{"name":"Bob2"}`, "member_4": `{"name":"Dave"}`})
+	if members, _ := lb.Top(ctx, 4); members[1].GetData() != `{"name":"Bob2"}` || members[3].GetData() != `{"name":"Dave"}` {
+		t.Error("Leaderboard UpdateMembersData Err!", members)
+	}
+
+	lb.RemoveMember(ctx, "member_1")
+	if _, err := lb.MemberDataFor(ctx, "member_1"); err == nil {
+		t.Error("Leaderboard RemoveMember Err!", err)
+	}
+	lb.RemoveMembersInScoreRange(ctx, 35, 45)
+	if _, err := lb.MemberDataFor(ctx, "member_2"); err == nil {
+		t.Error("Leaderboard RemoveMembersInScoreRange Err!", err)
+	}
+	lb.Delete(ctx)
+	if _, err := lb.MemberDataFor(ctx, "member_3"); err == nil {
+		t.Error("Leaderboard Delete Err!", err)
+	}
+}
+
+func TestMemberData(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	testMemberData(t, backend)
+	testMemberData(t, NewMemoryBackend())
+}
+
+// failingHMGet : backend whose hash lookups fail.
+type failingHMGet struct {
+	Backend
+}
+
+func (b failingHMGet) HMGet(ctx context.Context, key string, fields ...string) (map[string]string, error) {
+	return nil, errors.New("hmget failed")
+}
+
+func TestMemberDataErr(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	ctx := context.Background()
+	lb := NewLeaderboardWithBackend(failingHMGet{NewMemoryBackend()}, lbName, WithMemberData())
+	lb.RankMember(ctx, "member_1", 10)
+
+	if _, err := lb.ScoreAndRankFor(ctx, "member_1"); err == nil {
+		t.Error("Leaderboard ScoreAndRankFor Err!", err)
+	}
+	if _, err := lb.Members(ctx, 1, 10); err == nil {
+		t.Error("Leaderboard Members Err!", err)
+	}
+	if _, err := lb.RankedInList(ctx, []string{"member_1"}); err == nil {
+		t.Error("Leaderboard RankedInList Err!", err)
+	}
+	if _, err := lb.Friends(ctx, "member_1", nil); err == nil {
+		t.Error("Leaderboard Friends Err!", err)
+	}
+}
@@ -0,0 +1,1408 @@
+package rank
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// sortedSet : in memory zset. dict for member lookup, skiplist for order.
+type sortedSet struct {
+	dict map[string]float64
+	list *skiplist
+}
+
+func newSortedSet() *sortedSet {
+	return &sortedSet{dict: make(map[string]float64), list: newSkiplist()}
+}
+
+func (z *sortedSet) add(member string, score float64) {
+	if old, ok := z.dict[member]; ok {
+		if old == score {
+			return
+		}
+		z.list.delete(old, member)
+	}
+	z.dict[member] = score
+	z.list.insert(score, member)
+}
+
+func (z *sortedSet) remove(member string) bool {
+	score, ok := z.dict[member]
+	if !ok {
+		return false
+	}
+	delete(z.dict, member)
+	z.list.delete(score, member)
+	return true
+}
+
+// count : number of members in the score range.
+func (z *sortedSet) count(min string, max string) (int, error) {
+	minScore, minExclusive, err := parseScoreBound(min)
+	if err != nil {
+		return 0, err
+	}
+	maxScore, maxExclusive, err := parseScoreBound(max)
+	if err != nil {
+		return 0, err
+	}
+
+	count := z.list.countBelow(maxScore, !maxExclusive) - z.list.countBelow(minScore, minExclusive)
+	if count < 0 {
+		return 0, nil
+	}
+	return count, nil
+}
+
+// countDistinct : number of distinct scores in the score range.
+func (z *sortedSet) countDistinct(min string, max string) (int, error) {
+	minScore, minExclusive, err := parseScoreBound(min)
+	if err != nil {
+		return 0, err
+	}
+	maxScore, maxExclusive, err := parseScoreBound(max)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	x := z.list.byRank(z.list.countBelow(minScore, minExclusive) + 1)
+	for last := x; x != nil; x = x.level[0].forward {
+		if x.score > maxScore || (maxExclusive && x.score == maxScore) {
+			break
+		}
+		if count == 0 || x.score != last.score {
+			count++
+		}
+		last = x
+	}
+	return count, nil
+}
+
+// rangeByScore : members in the score range from the lowest score, skipping offset and up to count. count < 0 is all.
+func (z *sortedSet) rangeByScore(min string, max string, offset int, count int) ([]Entry, error) {
+	minScore, minExclusive, err := parseScoreBound(min)
+	if err != nil {
+		return nil, err
+	}
+	maxScore, maxExclusive, err := parseScoreBound(max)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []Entry
+	x := z.list.byRank(z.list.countBelow(minScore, minExclusive) + 1 + offset)
+	for ; x != nil && count != 0; x = x.level[0].forward {
+		if x.score > maxScore || (maxExclusive && x.score == maxScore) {
+			break
+		}
+		res = append(res, Entry{Member: x.member, Score: x.score})
+		count--
+	}
+	return res, nil
+}
+
+// revRangeByScore : members in the score range from the highest score, skipping offset and up to count. count < 0 is all.
+func (z *sortedSet) revRangeByScore(max string, min string, offset int, count int) ([]Entry, error) {
+	maxScore, maxExclusive, err := parseScoreBound(max)
+	if err != nil {
+		return nil, err
+	}
+	minScore, minExclusive, err := parseScoreBound(min)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []Entry
+	x := z.list.byRank(z.list.countBelow(maxScore, !maxExclusive) - offset)
+	for ; x != nil && count != 0; x = x.backward {
+		if x.score < minScore || (minExclusive && x.score == minScore) {
+			break
+		}
+		res = append(res, Entry{Member: x.member, Score: x.score})
+		count--
+	}
+	return res, nil
+}
+
+// top : members ranked n or better under mode, from the highest score when reverse, best first.
+// times holds the submission times ordering the ties of RankEarliestFirst.
+func (z *sortedSet) top(n int, reverse bool, mode RankingMode, times map[string]string) []string {
+	if n < 1 || z.list.length == 0 {
+		return nil
+	}
+
+	first, next := z.list.byRank(1), func(x *skiplistNode) *skiplistNode { return x.level[0].forward }
+	if reverse {
+		first, next = z.list.byRank(z.list.length), func(x *skiplistNode) *skiplistNode { return x.backward }
+	}
+
+	// edge : worst score of the top. the nth score, or the nth distinct score in RankDense.
+	var edge float64
+	if mode == RankDense {
+		distinct := 0
+		for x := first; x != nil && distinct < n; x = next(x) {
+			if distinct == 0 || x.score != edge {
+				edge, distinct = x.score, distinct+1
+			}
+		}
+	} else if n < z.list.length {
+		edge = z.list.byRank(n).score
+		if reverse {
+			edge = z.list.byRank(z.list.length - n + 1).score
+		}
+	} else {
+		edge = z.list.byRank(1).score
+		if !reverse {
+			edge = z.list.byRank(z.list.length).score
+		}
+	}
+
+	var res, ties []string
+	for x := first; x != nil && (x.score == edge || (x.score > edge) == reverse); x = next(x) {
+		if x.score == edge {
+			ties = append(ties, x.member)
+		} else {
+			res = append(res, x.member)
+		}
+	}
+
+	switch mode {
+	case RankOrdinal:
+		if len(res)+len(ties) > n {
+			ties = ties[:n-len(res)]
+		}
+	case RankEarliestFirst:
+		timestamps := make(map[string]int64, len(ties))
+		for _, member := range ties {
+			timestamps[member] = math.MaxInt64
+			if value, ok := times[member]; ok {
+				timestamps[member], _ = strconv.ParseInt(value, 10, 64)
+			}
+		}
+		sort.SliceStable(ties, func(i, j int) bool {
+			return timestamps[ties[i]] < timestamps[ties[j]]
+		})
+		if len(res)+len(ties) > n {
+			ties = ties[:n-len(res)]
+		}
+	}
+	return append(res, ties...)
+}
+
+// memoryBackend : in process Backend. gives the same results as redis without a server.
+type memoryBackend struct {
+	mu      sync.RWMutex
+	sets    map[string]*sortedSet
+	hashes  map[string]map[string]string
+	groups  map[string]map[string]struct{}
+	expires map[string]time.Time
+
+	// pub/sub and streams are not keys and do not expire.
+	subscribers map[string]map[chan string]struct{}
+	streams     map[string]*stream
+}
+
+// stream : append only message log.
+type stream struct {
+	messages []StreamMessage
+	lastTime int64
+	lastSeq  int64
+	// added : closed and replaced on every XAdd, to wake up blocked readers.
+	added chan struct{}
+}
+
+// NewMemoryBackend : create a Backend kept in process memory.
+func NewMemoryBackend() Backend {
+	return &memoryBackend{
+		sets:    make(map[string]*sortedSet),
+		hashes:  make(map[string]map[string]string),
+		groups:  make(map[string]map[string]struct{}),
+		expires: make(map[string]time.Time),
+
+		subscribers: make(map[string]map[chan string]struct{}),
+		streams:     make(map[string]*stream),
+	}
+}
+
+// expired : key is past its ExpireAt time. expired keys read as missing until they are dropped.
+func (b *memoryBackend) expired(key string) bool {
+	t, ok := b.expires[key]
+	return ok && !time.Now().Before(t)
+}
+
+// drop : remove key of any type. needs the write lock.
+func (b *memoryBackend) drop(key string) {
+	delete(b.sets, key)
+	delete(b.hashes, key)
+	delete(b.groups, key)
+	delete(b.expires, key)
+}
+
+// set : sorted set stored at key. create is only allowed under the write lock.
+func (b *memoryBackend) set(key string, create bool) *sortedSet {
+	z, ok := b.sets[key]
+	if ok && b.expired(key) {
+		z, ok = nil, false
+		if create {
+			b.drop(key)
+		}
+	}
+	if !ok && create {
+		z = newSortedSet()
+		b.sets[key] = z
+	}
+	return z
+}
+
+// hash : hash stored at key. create is only allowed under the write lock.
+func (b *memoryBackend) hash(key string, create bool) map[string]string {
+	h, ok := b.hashes[key]
+	if ok && b.expired(key) {
+		h, ok = nil, false
+		if create {
+			b.drop(key)
+		}
+	}
+	if !ok && create {
+		h = make(map[string]string)
+		b.hashes[key] = h
+	}
+	return h
+}
+
+// group : plain set stored at key. create is only allowed under the write lock.
+func (b *memoryBackend) group(key string, create bool) map[string]struct{} {
+	g, ok := b.groups[key]
+	if ok && b.expired(key) {
+		g, ok = nil, false
+		if create {
+			b.drop(key)
+		}
+	}
+	if !ok && create {
+		g = make(map[string]struct{})
+		b.groups[key] = g
+	}
+	return g
+}
+
+// exists : a live key of any type is stored at key.
+func (b *memoryBackend) exists(key string) bool {
+	return b.set(key, false) != nil || b.hash(key, false) != nil || b.group(key, false) != nil
+}
+
+// cleanup : redis removes a key when the last member is removed.
+func (b *memoryBackend) cleanup(key string) {
+	if z, ok := b.sets[key]; ok && len(z.dict) == 0 {
+		b.drop(key)
+	}
+}
+
+func (b *memoryBackend) ZAdd(ctx context.Context, key string, entries ...Entry) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	for _, entry := range entries {
+		if math.IsNaN(entry.Score) {
+			return errNotFloat
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	z := b.set(key, true)
+	for _, entry := range entries {
+		z.add(entry.Member, entry.Score)
+	}
+	return nil
+}
+
+// errNotFloat : the redis error for a NaN score.
+var errNotFloat = errors.New("ERR value is not a valid float")
+
+func (b *memoryBackend) ZAddEach(ctx context.Context, key string, entries []Entry, chunkSize int, atomic bool) ([]AddResult, error) {
+	results := make([]AddResult, len(entries))
+	if err := ctx.Err(); err != nil {
+		for i := range results {
+			results[i].Err = err
+		}
+		return results, err
+	}
+	if len(entries) == 0 {
+		return results, nil
+	}
+
+	// chunks only bound the size of a round trip, which the memory backend does not have.
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	z := b.set(key, true)
+	for i, entry := range entries {
+		if math.IsNaN(entry.Score) {
+			results[i].Err = errNotFloat
+			continue
+		}
+		old, found := z.dict[entry.Member]
+		z.add(entry.Member, entry.Score)
+		results[i].Added, results[i].Changed = !found, !found || old != entry.Score
+	}
+	return results, nil
+}
+
+func (b *memoryBackend) ZIncrBy(ctx context.Context, key string, member string, delta float64) (float64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	z := b.set(key, true)
+	score := z.dict[member] + delta
+	z.add(member, score)
+	return score, nil
+}
+
+func (b *memoryBackend) ZUpdate(ctx context.Context, key string, policy UpdatePolicy, entries ...Entry) ([]ScoreUpdate, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return []ScoreUpdate{}, nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	z := b.set(key, true)
+	res := make([]ScoreUpdate, 0, len(entries))
+	for _, entry := range entries {
+		old, found := z.dict[entry.Member]
+		score, changed := policy.apply(old, found, entry.Score)
+		if changed {
+			z.add(entry.Member, score)
+		}
+		res = append(res, ScoreUpdate{Member: entry.Member, Changed: changed})
+	}
+
+	for i := range res {
+		res[i].Score = z.dict[res[i].Member]
+		higher, err := z.count(scoreBound(res[i].Score, true), "+inf")
+		if err != nil {
+			return nil, err
+		}
+		res[i].Higher = higher
+		if res[i].Lower, err = z.count("-inf", scoreBound(res[i].Score, true)); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (b *memoryBackend) ZRem(ctx context.Context, key string, members ...string) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	z := b.set(key, false)
+	if z == nil {
+		return 0, nil
+	}
+
+	count := 0
+	for _, member := range members {
+		if z.remove(member) {
+			count++
+		}
+	}
+	b.cleanup(key)
+	return count, nil
+}
+
+func (b *memoryBackend) ZScore(ctx context.Context, key string, member string) (float64, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, false, err
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	z := b.set(key, false)
+	if z == nil {
+		return 0, false, nil
+	}
+	score, ok := z.dict[member]
+	return score, ok, nil
+}
+
+func (b *memoryBackend) ZMScore(ctx context.Context, key string, members ...string) ([]float64, []bool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	scores, found := make([]float64, len(members)), make([]bool, len(members))
+	if z := b.set(key, false); z != nil {
+		for i, member := range members {
+			scores[i], found[i] = z.dict[member]
+		}
+	}
+	return scores, found, nil
+}
+
+func (b *memoryBackend) ZCard(ctx context.Context, key string) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	z := b.set(key, false)
+	if z == nil {
+		return 0, nil
+	}
+	return z.list.length, nil
+}
+
+func (b *memoryBackend) ZCount(ctx context.Context, key string, min string, max string) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	z := b.set(key, false)
+	if z == nil {
+		return 0, nil
+	}
+	return z.count(min, max)
+}
+
+func (b *memoryBackend) ZCountDistinct(ctx context.Context, key string, min string, max string) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	z := b.set(key, false)
+	if z == nil {
+		return 0, nil
+	}
+	return z.countDistinct(min, max)
+}
+
+func (b *memoryBackend) ZMCount(ctx context.Context, key string, ranges ...ScoreRange) ([]int, error) {
+	return b.counts(ctx, key, ranges, (*sortedSet).count)
+}
+
+func (b *memoryBackend) ZMCountDistinct(ctx context.Context, key string, ranges ...ScoreRange) ([]int, error) {
+	return b.counts(ctx, key, ranges, (*sortedSet).countDistinct)
+}
+
+// counts : count every range of the set under one lock.
+func (b *memoryBackend) counts(ctx context.Context, key string, ranges []ScoreRange, count func(z *sortedSet, min string, max string) (int, error)) ([]int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	res := make([]int, len(ranges))
+	z := b.set(key, false)
+	if z == nil {
+		return res, nil
+	}
+	for i, r := range ranges {
+		var err error
+		if res[i], err = count(z, r.Min, r.Max); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (b *memoryBackend) ZRank(ctx context.Context, key string, member string) (int, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return -1, false, err
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	z := b.set(key, false)
+	if z == nil {
+		return -1, false, nil
+	}
+	score, ok := z.dict[member]
+	if !ok {
+		return -1, false, nil
+	}
+	return z.list.rank(score, member) - 1, true, nil
+}
+
+func (b *memoryBackend) ZRevRank(ctx context.Context, key string, member string) (int, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return -1, false, err
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	z := b.set(key, false)
+	if z == nil {
+		return -1, false, nil
+	}
+	score, ok := z.dict[member]
+	if !ok {
+		return -1, false, nil
+	}
+	return z.list.length - z.list.rank(score, member), true, nil
+}
+
+func (b *memoryBackend) ZRankOf(ctx context.Context, key string, score float64, member string, reverse bool) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	z := b.set(key, false)
+	if z == nil {
+		return 0, nil
+	}
+	before := z.list.countBefore(score, member)
+	if !reverse {
+		return before, nil
+	}
+	if old, ok := z.dict[member]; ok && old == score {
+		before++
+	}
+	return z.list.length - before, nil
+}
+
+func (b *memoryBackend) ZMRank(ctx context.Context, key string, members ...string) ([]int, []bool, error) {
+	return b.ranks(ctx, key, members, false)
+}
+
+func (b *memoryBackend) ZMRevRank(ctx context.Context, key string, members ...string) ([]int, []bool, error) {
+	return b.ranks(ctx, key, members, true)
+}
+
+// ranks : zero based positions of members under one lock. ranks of non-existent members are -1.
+func (b *memoryBackend) ranks(ctx context.Context, key string, members []string, reverse bool) ([]int, []bool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	ranks, found := make([]int, len(members)), make([]bool, len(members))
+	z := b.set(key, false)
+	for i, member := range members {
+		ranks[i] = -1
+		if z == nil {
+			continue
+		}
+		score, ok := z.dict[member]
+		if !ok {
+			continue
+		}
+		if reverse {
+			ranks[i] = z.list.length - z.list.rank(score, member)
+		} else {
+			ranks[i] = z.list.rank(score, member) - 1
+		}
+		found[i] = true
+	}
+	return ranks, found, nil
+}
+
+func (b *memoryBackend) ZRange(ctx context.Context, key string, start int, stop int) ([]Entry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	z := b.set(key, false)
+	if z == nil {
+		return []Entry{}, nil
+	}
+
+	start, stop, ok := normalizeIndexRange(start, stop, z.list.length)
+	if !ok {
+		return []Entry{}, nil
+	}
+
+	res := make([]Entry, 0, stop-start+1)
+	x := z.list.byRank(start + 1)
+	for i := start; i <= stop && x != nil; i++ {
+		res = append(res, Entry{Member: x.member, Score: x.score})
+		x = x.level[0].forward
+	}
+	return res, nil
+}
+
+func (b *memoryBackend) ZRevRange(ctx context.Context, key string, start int, stop int) ([]Entry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	z := b.set(key, false)
+	if z == nil {
+		return []Entry{}, nil
+	}
+
+	start, stop, ok := normalizeIndexRange(start, stop, z.list.length)
+	if !ok {
+		return []Entry{}, nil
+	}
+
+	res := make([]Entry, 0, stop-start+1)
+	x := z.list.byRank(z.list.length - start)
+	for i := start; i <= stop && x != nil; i++ {
+		res = append(res, Entry{Member: x.member, Score: x.score})
+		x = x.backward
+	}
+	return res, nil
+}
+
+func (b *memoryBackend) ZRangeByScore(ctx context.Context, key string, min string, max string) ([]Entry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	z := b.set(key, false)
+	if z == nil {
+		return []Entry{}, nil
+	}
+	return z.rangeByScore(min, max, 0, -1)
+}
+
+func (b *memoryBackend) ZMRangeByScore(ctx context.Context, key string, ranges ...ScoreRange) ([][]Entry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	res := make([][]Entry, len(ranges))
+	z := b.set(key, false)
+	for i, r := range ranges {
+		res[i] = []Entry{}
+		if z == nil {
+			continue
+		}
+		var err error
+		if res[i], err = z.rangeByScore(r.Min, r.Max, 0, -1); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (b *memoryBackend) ZRangeByScoreLimit(ctx context.Context, key string, min string, max string, offset int, count int) ([]Entry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	z := b.set(key, false)
+	if z == nil || offset < 0 {
+		return []Entry{}, nil
+	}
+	return z.rangeByScore(min, max, offset, count)
+}
+
+func (b *memoryBackend) ZRevRangeByScore(ctx context.Context, key string, max string, min string) ([]Entry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	z := b.set(key, false)
+	if z == nil {
+		return []Entry{}, nil
+	}
+	return z.revRangeByScore(max, min, 0, -1)
+}
+
+func (b *memoryBackend) ZRevRangeByScoreLimit(ctx context.Context, key string, max string, min string, offset int, count int) ([]Entry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	z := b.set(key, false)
+	if z == nil || offset < 0 {
+		return []Entry{}, nil
+	}
+	return z.revRangeByScore(max, min, offset, count)
+}
+
+func (b *memoryBackend) ZRemRangeByScore(ctx context.Context, key string, min string, max string) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	z := b.set(key, false)
+	if z == nil {
+		return 0, nil
+	}
+
+	members, err := z.revRangeByScore(max, min, 0, -1)
+	if err != nil {
+		return 0, err
+	}
+	for _, entry := range members {
+		z.remove(entry.Member)
+	}
+	b.cleanup(key)
+	return len(members), nil
+}
+
+func (b *memoryBackend) ZRemDue(ctx context.Context, key string, max string, target string, others ...string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	z := b.set(key, false)
+	if z == nil {
+		return []string{}, nil
+	}
+	due, err := z.rangeByScore("-inf", max, 0, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	removed := []string{}
+	for _, entry := range due {
+		for _, other := range others {
+			if o := b.set(other, false); o != nil {
+				o.remove(entry.Member)
+			}
+		}
+		z.remove(entry.Member)
+		if t := b.set(target, false); t != nil && t.remove(entry.Member) {
+			removed = append(removed, entry.Member)
+		}
+	}
+	for _, k := range append([]string{key, target}, others...) {
+		b.cleanup(k)
+	}
+	return removed, nil
+}
+
+func (b *memoryBackend) ZScaleExp2(ctx context.Context, key string, exp float64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	z := b.set(key, false)
+	if z == nil {
+		return nil
+	}
+
+	// in two halves, like the redis script.
+	half := math.Exp2(exp / 2)
+	scaled := newSortedSet()
+	for member, score := range z.dict {
+		scaled.add(member, score*half*half)
+	}
+	*z = *scaled
+	return nil
+}
+
+func (b *memoryBackend) ZRemRangeByRank(ctx context.Context, key string, start int, stop int) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	z := b.set(key, false)
+	if z == nil {
+		return 0, nil
+	}
+
+	start, stop, ok := normalizeIndexRange(start, stop, z.list.length)
+	if !ok {
+		return 0, nil
+	}
+
+	var members []string
+	for x := z.list.byRank(start + 1); x != nil && len(members) < stop-start+1; x = x.level[0].forward {
+		members = append(members, x.member)
+	}
+	for _, member := range members {
+		z.remove(member)
+	}
+	b.cleanup(key)
+	return len(members), nil
+}
+
+func (b *memoryBackend) ZUnionStore(ctx context.Context, dest string, keys []string, weights []float64, aggregate Aggregate) (int, error) {
+	return b.store(ctx, dest, keys, weights, aggregate, false)
+}
+
+func (b *memoryBackend) ZInterStore(ctx context.Context, dest string, keys []string, weights []float64, aggregate Aggregate) (int, error) {
+	return b.store(ctx, dest, keys, weights, aggregate, true)
+}
+
+// store : ZUnionStore, or ZInterStore when inter is set.
+func (b *memoryBackend) store(ctx context.Context, dest string, keys []string, weights []float64, aggregate Aggregate, inter bool) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	scores := make(map[string]float64)
+	counts := make(map[string]int)
+	for i, key := range keys {
+		weight := 1.0
+		if weights != nil {
+			weight = weights[i]
+		}
+		z := b.set(key, false)
+		if z == nil {
+			continue
+		}
+		for member, score := range z.dict {
+			score *= weight
+			if old, ok := scores[member]; ok {
+				score = aggregate.apply(old, score)
+			}
+			scores[member] = score
+			counts[member]++
+		}
+	}
+
+	b.drop(dest)
+	z := newSortedSet()
+	for member, score := range scores {
+		if !inter || counts[member] == len(keys) {
+			z.add(member, score)
+		}
+	}
+	if len(z.dict) > 0 {
+		b.sets[dest] = z
+	}
+	return len(z.dict), nil
+}
+
+func (b *memoryBackend) ZInter(ctx context.Context, key string, setKey string) ([]Entry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	res := []Entry{}
+	z := b.set(key, false)
+	if z == nil {
+		return res, nil
+	}
+	for member := range b.group(setKey, false) {
+		if score, ok := z.dict[member]; ok {
+			res = append(res, Entry{Member: member, Score: score})
+		}
+	}
+	return res, nil
+}
+
+func (b *memoryBackend) ZSumStore(ctx context.Context, dest string, member string, src string, start int, stop int, reverse bool) (float64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sum, empty := 0.0, true
+	if z := b.set(src, false); z != nil {
+		if start, stop, ok := normalizeIndexRange(start, stop, z.list.length); ok {
+			x := z.list.byRank(start + 1)
+			if reverse {
+				x = z.list.byRank(z.list.length - start)
+			}
+			for i := start; i <= stop && x != nil; i++ {
+				sum, empty = sum+x.score, false
+				if reverse {
+					x = x.backward
+				} else {
+					x = x.level[0].forward
+				}
+			}
+		}
+	}
+
+	if empty {
+		if z := b.set(dest, false); z != nil {
+			z.remove(member)
+			b.cleanup(dest)
+		}
+		return 0, nil
+	}
+	b.set(dest, true).add(member, sum)
+	return sum, nil
+}
+
+func (b *memoryBackend) ZTopStore(ctx context.Context, dest string, src string, n int, reverse bool, mode RankingMode, timestamps string) ([]string, []string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var top []string
+	if z := b.set(src, false); z != nil {
+		top = z.top(n, reverse, mode, b.hash(timestamps, false))
+	}
+
+	entered, left := []string{}, []string{}
+	inTop := make(map[string]bool, len(top))
+	g := b.group(dest, true)
+	for _, member := range top {
+		inTop[member] = true
+		if _, ok := g[member]; !ok {
+			g[member] = struct{}{}
+			entered = append(entered, member)
+		}
+	}
+	for member := range g {
+		if !inTop[member] {
+			delete(g, member)
+			left = append(left, member)
+		}
+	}
+	if len(g) == 0 {
+		b.drop(dest)
+	}
+	return entered, left, nil
+}
+
+func (b *memoryBackend) HSet(ctx context.Context, key string, values map[string]string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	h := b.hash(key, true)
+	for field, value := range values {
+		h[field] = value
+	}
+	return nil
+}
+
+func (b *memoryBackend) HMGet(ctx context.Context, key string, fields ...string) (map[string]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	res := make(map[string]string, len(fields))
+	h := b.hash(key, false)
+	for _, field := range fields {
+		if value, ok := h[field]; ok {
+			res[field] = value
+		}
+	}
+	return res, nil
+}
+
+func (b *memoryBackend) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	h := b.hash(key, false)
+	res := make(map[string]string, len(h))
+	for field, value := range h {
+		res[field] = value
+	}
+	return res, nil
+}
+
+func (b *memoryBackend) HDel(ctx context.Context, key string, fields ...string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	h := b.hash(key, false)
+	if h == nil {
+		return nil
+	}
+	for _, field := range fields {
+		delete(h, field)
+	}
+	if len(h) == 0 {
+		b.drop(key)
+	}
+	return nil
+}
+
+func (b *memoryBackend) SAdd(ctx context.Context, key string, members ...string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if len(members) == 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	g := b.group(key, true)
+	for _, member := range members {
+		g[member] = struct{}{}
+	}
+	return nil
+}
+
+func (b *memoryBackend) SRem(ctx context.Context, key string, members ...string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	g := b.group(key, false)
+	if g == nil {
+		return nil
+	}
+	for _, member := range members {
+		delete(g, member)
+	}
+	if len(g) == 0 {
+		b.drop(key)
+	}
+	return nil
+}
+
+func (b *memoryBackend) SMembers(ctx context.Context, key string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	g := b.group(key, false)
+	res := make([]string, 0, len(g))
+	for member := range g {
+		res = append(res, member)
+	}
+	return res, nil
+}
+
+func (b *memoryBackend) Del(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.drop(key)
+	delete(b.streams, key)
+	return nil
+}
+
+func (b *memoryBackend) RenameNX(ctx context.Context, renames map[string]string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, newKey := range renames {
+		if _, ok := b.streams[newKey]; ok || b.exists(newKey) {
+			return false, nil
+		}
+	}
+	for key, newKey := range renames {
+		if s, ok := b.streams[key]; ok {
+			delete(b.streams, key)
+			b.streams[newKey] = s
+			continue
+		}
+		if !b.exists(key) {
+			continue
+		}
+		z, h, g := b.set(key, false), b.hash(key, false), b.group(key, false)
+		// the time to live moves with the key like redis RENAME.
+		t, expires := b.expires[key]
+		b.drop(key)
+		b.drop(newKey)
+		if z != nil {
+			b.sets[newKey] = z
+		}
+		if h != nil {
+			b.hashes[newKey] = h
+		}
+		if g != nil {
+			b.groups[newKey] = g
+		}
+		if expires {
+			b.expires[newKey] = t
+		}
+	}
+	return true, nil
+}
+
+func (b *memoryBackend) Publish(ctx context.Context, channel string, messages ...string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for subscriber := range b.subscribers[channel] {
+		for _, message := range messages {
+			// like redis, a subscriber that does not keep up loses messages instead of blocking the publisher.
+			select {
+			case subscriber <- message:
+			default:
+			}
+		}
+	}
+	return nil
+}
+
+func (b *memoryBackend) Subscribe(ctx context.Context, channel string) (<-chan string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	messages := make(chan string, 128)
+	if b.subscribers[channel] == nil {
+		b.subscribers[channel] = make(map[chan string]struct{})
+	}
+	b.subscribers[channel][messages] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subscribers[channel], messages)
+		if len(b.subscribers[channel]) == 0 {
+			delete(b.subscribers, channel)
+		}
+		close(messages)
+	}()
+	return messages, nil
+}
+
+func (b *memoryBackend) XAdd(ctx context.Context, key string, maxLen int, messages ...string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	s := b.stream(key)
+	for _, message := range messages {
+		// ids are "<unix ms>-<sequence>" and always increase, like redis.
+		now := time.Now().UnixNano() / int64(time.Millisecond)
+		if now > s.lastTime {
+			s.lastTime, s.lastSeq = now, 0
+		} else {
+			s.lastSeq++
+		}
+		id := strconv.FormatInt(s.lastTime, 10) + "-" + strconv.FormatInt(s.lastSeq, 10)
+		s.messages = append(s.messages, StreamMessage{ID: id, Message: message})
+	}
+	if maxLen > 0 && len(s.messages) > maxLen {
+		s.messages = append([]StreamMessage(nil), s.messages[len(s.messages)-maxLen:]...)
+	}
+	close(s.added)
+	s.added = make(chan struct{})
+	return nil
+}
+
+// stream : stream stored at key, created when missing. needs the write lock.
+func (b *memoryBackend) stream(key string) *stream {
+	s, ok := b.streams[key]
+	if !ok {
+		s = &stream{added: make(chan struct{})}
+		b.streams[key] = s
+	}
+	return s
+}
+
+func (b *memoryBackend) XRead(ctx context.Context, key string, lastID string, count int, block time.Duration) ([]StreamMessage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	s := b.stream(key)
+	if lastID == "$" {
+		lastID = "0-0"
+		if len(s.messages) > 0 {
+			lastID = s.messages[len(s.messages)-1].ID
+		}
+	}
+	after, err := parseStreamID(lastID)
+	if err != nil {
+		b.mu.Unlock()
+		return nil, err
+	}
+
+	var timeout <-chan time.Time
+	if block > 0 {
+		timer := time.NewTimer(block)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	for {
+		res := []StreamMessage{}
+		for _, message := range s.messages {
+			if id, _ := parseStreamID(message.ID); lessStreamID(after, id) {
+				res = append(res, message)
+				if count > 0 && len(res) == count {
+					break
+				}
+			}
+		}
+		if len(res) > 0 || timeout == nil {
+			b.mu.Unlock()
+			return res, nil
+		}
+
+		added := s.added
+		b.mu.Unlock()
+		select {
+		case <-added:
+		case <-timeout:
+			return []StreamMessage{}, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		b.mu.Lock()
+	}
+}
+
+// parseStreamID : time and sequence of a stream message id. a missing sequence is 0.
+func parseStreamID(id string) ([2]int64, error) {
+	var res [2]int64
+	parts := strings.SplitN(id, "-", 2)
+	var err error
+	if res[0], err = strconv.ParseInt(parts[0], 10, 64); err != nil {
+		return res, err
+	}
+	if len(parts) == 2 {
+		if res[1], err = strconv.ParseInt(parts[1], 10, 64); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+func lessStreamID(a [2]int64, b [2]int64) bool {
+	return a[0] < b[0] || (a[0] == b[0] && a[1] < b[1])
+}
+
+func (b *memoryBackend) ExpireAt(ctx context.Context, key string, t time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// expired keys are dropped here, so keys nobody reads again do not pile up.
+	for k := range b.expires {
+		if b.expired(k) {
+			b.drop(k)
+		}
+	}
+
+	if !b.exists(key) {
+		return nil
+	}
+	b.expires[key] = t
+	if b.expired(key) {
+		b.drop(key)
+	}
+	return nil
+}
+
+func (b *memoryBackend) Close() error {
+	return nil
+}
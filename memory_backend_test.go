@@ -0,0 +1,112 @@
+package rank
+
+import (
+	"context"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestMemoryBackend(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	ctx := context.Background()
+	lb := NewLeaderboardWithBackend(NewMemoryBackend(), lbName)
+
+	lb.RankMember(ctx, "member_1", 50)
+	lb.RankMember(ctx, "member_2", 50)
+	lb.RankMember(ctx, "member_3", 30)
+	lb.RankMember(ctx, "member_4", 30)
+	lb.RankMember(ctx, "member_5", 10)
+
+	if rank, _ := lb.RankFor(ctx, "member_2"); rank != 1 {
+		t.Error("MemoryBackend RankFor Err!", rank)
+	}
+	if rank, _ := lb.RankFor(ctx, "member_4"); rank != 3 {
+		t.Error("MemoryBackend RankFor Err!", rank)
+	}
+	if members, _ := lb.Members(ctx, 1, 0); len(members) != 5 ||
+		members[0].Member != "member_2" || members[4].Member != "member_5" {
+		t.Error("MemoryBackend Members Err!", members)
+	}
+	if percentile, _ := lb.PercentileFor(ctx, "member_5"); percentile != 0 {
+		t.Error("MemoryBackend PercentileFor Err!", percentile)
+	}
+	if count, _ := lb.RemoveMembersOutsideRank(ctx, 2); count != 3 {
+		t.Error("MemoryBackend RemoveMembersOutsideRank Err!", count)
+	}
+	if totalMembers, _ := lb.TotalMembers(ctx); totalMembers != 2 {
+		t.Error("MemoryBackend TotalMembers Err!", totalMembers)
+	}
+}
+
+func TestMemoryBackendMatchesRedis(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	ctx := context.Background()
+	redisLb := NewLeaderboardWithBackend(backend, lbName)
+	memoryLb := NewLeaderboardWithBackend(NewMemoryBackend(), lbName)
+	defer redisLb.Delete(ctx)
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		member := "member_" + strconv.Itoa(r.Intn(100))
+		score := float64(r.Intn(50))
+		switch r.Intn(4) {
+		case 0:
+			redisLb.ChangeScoreFor(ctx, member, score-25)
+			memoryLb.ChangeScoreFor(ctx, member, score-25)
+		case 1:
+			redisLb.RemoveMember(ctx, member)
+			memoryLb.RemoveMember(ctx, member)
+		default:
+			redisLb.RankMember(ctx, member, score)
+			memoryLb.RankMember(ctx, member, score)
+		}
+	}
+
+	for _, member := range []string{"member_1", "member_10", "member_50", "member_99"} {
+		redisRank, _ := redisLb.RankFor(ctx, member)
+		memoryRank, _ := memoryLb.RankFor(ctx, member)
+		if redisRank != memoryRank {
+			t.Error("MemoryBackend RankFor Err!", member, redisRank, memoryRank)
+		}
+
+		redisPercentile, _ := redisLb.PercentileFor(ctx, member)
+		memoryPercentile, _ := memoryLb.PercentileFor(ctx, member)
+		if redisPercentile != memoryPercentile {
+			t.Error("MemoryBackend PercentileFor Err!", member, redisPercentile, memoryPercentile)
+		}
+
+		redisMembers, _ := redisLb.AroundMe(ctx, member, 10)
+		memoryMembers, _ := memoryLb.AroundMe(ctx, member, 10)
+		if !reflect.DeepEqual(redisMembers, memoryMembers) {
+			t.Error("MemoryBackend AroundMe Err!", member, redisMembers, memoryMembers)
+		}
+	}
+
+	for page := 1; page <= 5; page++ {
+		redisMembers, _ := redisLb.Members(ctx, page, 10)
+		memoryMembers, _ := memoryLb.Members(ctx, page, 10)
+		if !reflect.DeepEqual(redisMembers, memoryMembers) {
+			t.Error("MemoryBackend Members Err!", page, redisMembers, memoryMembers)
+		}
+	}
+
+	redisMembers, _ := redisLb.MembersFromScoreRange(ctx, 10, 30)
+	memoryMembers, _ := memoryLb.MembersFromScoreRange(ctx, 10, 30)
+	if !reflect.DeepEqual(redisMembers, memoryMembers) {
+		t.Error("MemoryBackend MembersFromScoreRange Err!", redisMembers, memoryMembers)
+	}
+
+	redisCount, _ := redisLb.TotalMembersInScoreRange(ctx, -5, 20)
+	memoryCount, _ := memoryLb.TotalMembersInScoreRange(ctx, -5, 20)
+	if redisCount != memoryCount {
+		t.Error("MemoryBackend TotalMembersInScoreRange Err!", redisCount, memoryCount)
+	}
+
+	redisScore, _ := redisLb.ScoreForPercentile(ctx, 75)
+	memoryScore, _ := memoryLb.ScoreForPercentile(ctx, 75)
+	if redisScore != memoryScore {
+		t.Error("MemoryBackend ScoreForPercentile Err!", redisScore, memoryScore)
+	}
+}
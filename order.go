@@ -0,0 +1,95 @@
+package rank
+
+import (
+	"context"
+)
+
+// SortOrder : which end of the score range ranks first.
+type SortOrder int
+
+const (
+	// Descending : the highest score wins.
+	Descending SortOrder = iota
+	// Ascending : the lowest score wins. e.g. speedrun times or golf.
+	Ascending
+)
+
+// WithSortOrder : rank the members of the leaderboard in order. default Descending.
+func WithSortOrder(order SortOrder) Option {
+	return func(lb *Leaderboard) {
+		lb.order = order
+	}
+}
+
+// better : score a ranks above score b.
+func (lb *Leaderboard) better(a float64, b float64) bool {
+	if lb.order == Ascending {
+		return a < b
+	}
+	return a > b
+}
+
+// betterRange : min and max bounds of the scores ranked above score.
+func (lb *Leaderboard) betterRange(score float64) (string, string) {
+	if lb.order == Ascending {
+		return "-inf", scoreBound(score, true)
+	}
+	return scoreBound(score, true), "+inf"
+}
+
+// worseRange : min and max bounds of the scores ranked below score.
+func (lb *Leaderboard) worseRange(score float64) (string, string) {
+	if lb.order == Ascending {
+		return scoreBound(score, true), "+inf"
+	}
+	return "-inf", scoreBound(score, true)
+}
+
+// byIndex : entries at the zero based indexes start..stop from the best score.
+func (lb *Leaderboard) byIndex(ctx context.Context, start int, stop int) ([]Entry, error) {
+	if lb.order == Ascending {
+		return lb.backend.ZRange(ctx, lb.Name, start, stop)
+	}
+	return lb.backend.ZRevRange(ctx, lb.Name, start, stop)
+}
+
+// indexOf : zero based index of member from the best score.
+func (lb *Leaderboard) indexOf(ctx context.Context, member string) (int, bool, error) {
+	if lb.order == Ascending {
+		return lb.backend.ZRank(ctx, lb.Name, member)
+	}
+	return lb.backend.ZRevRank(ctx, lb.Name, member)
+}
+
+// indexesOf : indexOf of every member in one round trip.
+func (lb *Leaderboard) indexesOf(ctx context.Context, members ...string) ([]int, []bool, error) {
+	if lb.order == Ascending {
+		return lb.backend.ZMRank(ctx, lb.Name, members...)
+	}
+	return lb.backend.ZMRevRank(ctx, lb.Name, members...)
+}
+
+// byScore : entries with a score in the min and max bounds, from the best score.
+func (lb *Leaderboard) byScore(ctx context.Context, min string, max string) ([]Entry, error) {
+	if lb.order == Ascending {
+		return lb.backend.ZRangeByScore(ctx, lb.Name, min, max)
+	}
+	return lb.backend.ZRevRangeByScore(ctx, lb.Name, max, min)
+}
+
+// byScoreLimit : up to count entries with a score in the min and max bounds after skipping offset,
+// from the best score, or from the worst when reverse.
+func (lb *Leaderboard) byScoreLimit(ctx context.Context, min string, max string, offset int, count int, reverse bool) ([]Entry, error) {
+	if (lb.order == Ascending) != reverse {
+		return lb.backend.ZRangeByScoreLimit(ctx, lb.Name, min, max, offset, count)
+	}
+	return lb.backend.ZRevRangeByScoreLimit(ctx, lb.Name, max, min, offset, count)
+}
+
+// removeWorse : remove the members after the first count indexes from the best score.
+func (lb *Leaderboard) removeWorse(ctx context.Context, count int) (int, error) {
+	if lb.order == Ascending {
+		return lb.backend.ZRemRangeByRank(ctx, lb.Name, count, -1)
+	}
+	return lb.backend.ZRemRangeByRank(ctx, lb.Name, 0, -count-1)
+}
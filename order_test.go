@@ -0,0 +1,59 @@
+package rank
+
+import (
+	"context"
+	"testing"
+)
+
+func testAscending(t *testing.T, b Backend) {
+	ctx := context.Background()
+	lb := NewLeaderboardWithBackend(b, lbName, WithSortOrder(Ascending))
+	defer lb.Delete(ctx)
+
+	// lap times. the lowest time wins.
+	lb.RankMember(ctx, "member_1", 62)
+	lb.RankMember(ctx, "member_2", 58)
+	lb.RankMember(ctx, "member_3", 58)
+	lb.RankMember(ctx, "member_4", 70)
+	lb.RankMember(ctx, "member_5", 81)
+
+	if rank, _ := lb.RankFor(ctx, "member_2"); rank != 1 {
+		t.Error("Ascending RankFor Err!", rank)
+	}
+	if rank, _ := lb.RankFor(ctx, "member_1"); rank != 3 {
+		t.Error("Ascending RankFor Err!", rank)
+	}
+	if members, _ := lb.Top(ctx, 3); len(members) != 3 ||
+		members[0].score != 58 || members[2].Member != "member_1" || members[2].rank != 3 {
+		t.Error("Ascending Top Err!", members)
+	}
+	if members, _ := lb.AroundMe(ctx, "member_4", 3); len(members) != 3 ||
+		members[0].Member != "member_1" || members[2].Member != "member_5" {
+		t.Error("Ascending AroundMe Err!", members)
+	}
+	if percentile, _ := lb.PercentileFor(ctx, "member_2"); percentile != 60 {
+		t.Error("Ascending PercentileFor Err!", percentile)
+	}
+	if score, _ := lb.ScoreForPercentile(ctx, 0); score != 58 {
+		t.Error("Ascending ScoreForPercentile Err!", score)
+	}
+	if members, _ := lb.MembersFromScoreRange(ctx, 60, 75); len(members) != 2 || members[0].Member != "member_1" {
+		t.Error("Ascending MembersFromScoreRange Err!", members)
+	}
+	if res, _ := lb.RankMemberWithPolicy(ctx, "member_4", 55, PolicyMin); !res.Changed || res.rank != 1 {
+		t.Error("Ascending RankMemberWithPolicy Err!", res)
+	}
+
+	if count, _ := lb.RemoveMembersOutsideRank(ctx, 2); count != 3 {
+		t.Error("Ascending RemoveMembersOutsideRank Err!", count)
+	}
+	if members, _ := lb.AllMembers(ctx); len(members) != 2 || members[0].Member != "member_4" || members[1].score != 58 {
+		t.Error("Ascending AllMembers Err!", members)
+	}
+}
+
+func TestAscending(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	testAscending(t, backend)
+	testAscending(t, NewMemoryBackend())
+}
@@ -0,0 +1,124 @@
+package rank
+
+import (
+	"context"
+	"fmt"
+)
+
+// UpdatePolicy : how a new score is combined with the stored score of a member.
+type UpdatePolicy string
+
+const (
+	// PolicyReplace : always store the new score.
+	PolicyReplace UpdatePolicy = "replace"
+	// PolicyIncrement : add the new score to the stored score.
+	PolicyIncrement UpdatePolicy = "increment"
+	// PolicyMax : keep the highest score. the best score of a Descending leaderboard.
+	PolicyMax UpdatePolicy = "max"
+	// PolicyMin : keep the lowest score. the best score of an Ascending leaderboard.
+	PolicyMin UpdatePolicy = "min"
+)
+
+// apply : resulting score and whether it differs from the stored one.
+func (p UpdatePolicy) apply(old float64, found bool, score float64) (float64, bool) {
+	if !found {
+		return score, true
+	}
+
+	switch p {
+	case PolicyIncrement:
+		return old + score, score != 0
+	case PolicyMax:
+		if score > old {
+			return score, true
+		}
+	case PolicyMin:
+		if score < old {
+			return score, true
+		}
+	default:
+		if score != old {
+			return score, true
+		}
+	}
+	return old, false
+}
+
+// UpdateResult : member score and rank after a conditional update.
+type UpdateResult struct {
+	RankScore
+	// Changed : false when the policy kept the stored score.
+	Changed bool
+}
+
+func (m *UpdateResult) String() string {
+	return fmt.Sprintf("%s changed:%t", m.RankScore.String(), m.Changed)
+}
+
+// RankMemberWithPolicy : Rank a member applying policy to the stored score, atomically.
+// returns the stored score, the new rank and whether the score changed. the rank is read in the same atomic
+// step only under RankStandard. other ranking modes read it after the write, and may see later writes.
+func (lb *Leaderboard) RankMemberWithPolicy(ctx context.Context, member string, score float64, policy UpdatePolicy) (*UpdateResult, error) {
+	res, err := lb.RankMembersWithPolicy(ctx, []*RankScore{{Member: member, score: score}}, policy)
+	if err != nil {
+		return nil, err
+	}
+	return res[0], nil
+}
+
+// RankMembersWithPolicy : Rank an array of members applying policy, atomically.
+// ranks are computed after the whole batch is applied, in the same atomic step only under RankStandard.
+func (lb *Leaderboard) RankMembersWithPolicy(ctx context.Context, membersAndScores []*RankScore, policy UpdatePolicy) ([]*UpdateResult, error) {
+	entries := make([]Entry, 0, len(membersAndScores))
+	members := make([]string, 0, len(membersAndScores))
+	for _, memberScore := range membersAndScores {
+		value, err := lb.stored(memberScore.score)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, Entry{Member: memberScore.Member, Score: value})
+		members = append(members, memberScore.Member)
+	}
+
+	before, err := lb.snapshot(ctx, members...)
+	if err != nil {
+		return nil, err
+	}
+	updates, err := lb.backend.ZUpdate(ctx, lb.Name, policy, entries...)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	for _, update := range updates {
+		if update.Changed {
+			changed = append(changed, update.Member)
+		}
+	}
+	if err := lb.touch(ctx, changed...); err != nil {
+		return nil, err
+	}
+	if err := lb.written(ctx, before, changed...); err != nil {
+		return nil, err
+	}
+
+	res := make([]*UpdateResult, 0, len(updates))
+	for _, update := range updates {
+		rank := update.Higher + 1
+		if lb.order == Ascending {
+			rank = update.Lower + 1
+		}
+		if lb.rankingMode != RankStandard {
+			// only standard ranks come back from the atomic update.
+			if rank, err = lb.rankFor(ctx, update.Member, update.Score); err != nil {
+				return nil, err
+			}
+		}
+
+		res = append(res, &UpdateResult{
+			RankScore: RankScore{Member: update.Member, score: lb.decode(update.Score), rank: rank},
+			Changed:   update.Changed,
+		})
+	}
+	return res, nil
+}
@@ -0,0 +1,58 @@
+package rank
+
+import (
+	"context"
+	"testing"
+)
+
+func testRankMemberWithPolicy(t *testing.T, lb *Leaderboard) {
+	ctx := context.Background()
+	defer lb.Delete(ctx)
+
+	lb.RankMember(ctx, "member_1", 50)
+	lb.RankMember(ctx, "member_2", 30)
+
+	if res, err := lb.RankMemberWithPolicy(ctx, "member_2", 20, PolicyMax); err != nil ||
+		res.Changed || res.score != 30 || res.rank != 2 {
+		t.Error("Leaderboard RankMemberWithPolicy Max Err!", res, err)
+	}
+	if res, _ := lb.RankMemberWithPolicy(ctx, "member_2", 60, PolicyMax); !res.Changed || res.score != 60 || res.rank != 1 {
+		t.Error("Leaderboard RankMemberWithPolicy Max Err!", res)
+	}
+	if res, _ := lb.RankMemberWithPolicy(ctx, "member_1", 70, PolicyMin); res.Changed || res.score != 50 || res.rank != 2 {
+		t.Error("Leaderboard RankMemberWithPolicy Min Err!", res)
+	}
+	if res, _ := lb.RankMemberWithPolicy(ctx, "member_3", 70, PolicyMin); !res.Changed || res.score != 70 || res.rank != 1 {
+		t.Error("Leaderboard RankMemberWithPolicy Min Err!", res)
+	}
+	if res, _ := lb.RankMemberWithPolicy(ctx, "member_3", 70, PolicyReplace); res.Changed || res.score != 70 {
+		t.Error("Leaderboard RankMemberWithPolicy Replace Err!", res)
+	}
+	if res, _ := lb.RankMemberWithPolicy(ctx, "member_1", 15, PolicyIncrement); !res.Changed || res.score != 65 || res.rank != 2 {
+		t.Error("Leaderboard RankMemberWithPolicy Increment Err!", res)
+	}
+
+	res, err := lb.RankMembersWithPolicy(ctx, []*RankScore{
+		NewRankScore("member_1", 100),
+		NewRankScore("member_2", 10),
+		NewRankScore("member_4", 5),
+	}, PolicyMax)
+	if err != nil || len(res) != 3 {
+		t.Fatal("Leaderboard RankMembersWithPolicy Err!", res, err)
+	}
+	if !res[0].Changed || res[0].score != 100 || res[0].rank != 1 {
+		t.Error("Leaderboard RankMembersWithPolicy Err!", res[0])
+	}
+	if res[1].Changed || res[1].score != 60 || res[1].rank != 3 {
+		t.Error("Leaderboard RankMembersWithPolicy Err!", res[1])
+	}
+	if !res[2].Changed || res[2].score != 5 || res[2].rank != 4 {
+		t.Error("Leaderboard RankMembersWithPolicy Err!", res[2])
+	}
+}
+
+func TestRankMemberWithPolicy(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	testRankMemberWithPolicy(t, NewLeaderboardWithBackend(backend, lbName))
+	testRankMemberWithPolicy(t, NewLeaderboardWithBackend(NewMemoryBackend(), lbName))
+}
@@ -1,32 +1,53 @@
 package rank
 
 import (
-	"math"
-	"strconv"
-
+	"context"
 	"fmt"
-
-	"github.com/gomodule/redigo/redis"
+	"time"
 )
 
-var conn redis.Conn
+// backend : default storage used by the package level functions.
+var backend Backend
 
 // InitRedis init redis connection
 func InitRedis(conf string) error {
 	// init redigo.
-	c, err := redis.Dial("tcp", conf)
-	if err != nil {
+	p := NewPool(conf)
+
+	conn := p.Get()
+	defer conn.Close()
+	if _, err := conn.Do("PING"); err != nil {
+		p.Close()
 		return err
 	}
-	conn = c
+	backend = NewRedisBackend(p)
 	return nil
 }
 
+// InitBackend : use b for the package level functions. e.g. NewMemoryBackend() in tests.
+func InitBackend(b Backend) {
+	backend = b
+}
+
 // Final close redis connection
 func Final() {
-	if conn != nil {
-		conn.Close()
+	if backend != nil {
+		backend.Close()
+	}
+}
+
+// defaultLeaderboard : leaderboard on the default backend.
+func defaultLeaderboard(lbName string) *Leaderboard {
+	return NewLeaderboardWithBackend(backend, lbName)
+}
+
+// defaultLeaderboards : leaderboards on the default backend.
+func defaultLeaderboards(lbNames []string) []*Leaderboard {
+	res := make([]*Leaderboard, 0, len(lbNames))
+	for _, lbName := range lbNames {
+		res = append(res, defaultLeaderboard(lbName))
 	}
+	return res
 }
 
 // DEFAULT_PAGESIZE : 25
@@ -35,12 +56,19 @@ const DEFAULT_PAGESIZE int = 25
 // RankScore : member score struct.
 type RankScore struct {
 	Member string
-	score  int
+	score  float64
 	rank   int
+	data   string
+	err    error
+}
+
+// NewRankScore : member and score for RankMembers.
+func NewRankScore(member string, score float64) *RankScore {
+	return &RankScore{Member: member, score: score}
 }
 
 // GetScore get rank score
-func (m *RankScore) GetScore() int {
+func (m *RankScore) GetScore() float64 {
 	return m.score
 }
 
@@ -49,8 +77,18 @@ func (m *RankScore) GetRank() int {
 	return m.rank
 }
 
+// GetData get member data. empty unless the leaderboard keeps member data.
+func (m *RankScore) GetData() string {
+	return m.data
+}
+
+// GetErr get why the member has no score and rank. ErrMemberNotFound for a member of RankedInList not in the leaderboard.
+func (m *RankScore) GetErr() error {
+	return m.err
+}
+
 func (m *RankScore) String() string {
-	return fmt.Sprintf("member:%s score:%d rank:%d", m.Member, m.score, m.rank)
+	return fmt.Sprintf("member:%s score:%g rank:%d", m.Member, m.score, m.rank)
 }
 
 // RankScores : rank score list
@@ -63,399 +101,214 @@ func (p RankScores) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
 */
 
 // RankMember :   Rank a member in the leaderboard.
-func RankMember(lbName string, member string, score int) error {
-	_, err := conn.Do("ZADD", lbName, score, member)
-	return err
+func RankMember(lbName string, member string, score float64) error {
+	return defaultLeaderboard(lbName).RankMember(context.Background(), member, score)
 }
 
 // RankMembers : Rank an array of members in the leaderboard.
 func RankMembers(lbName string, membersAndScores []*RankScore) error {
-	for _, memberScore := range membersAndScores {
-		conn.Send("ZADD", lbName, memberScore.score, memberScore.Member)
-	}
-	conn.Flush()
-	return nil
+	return defaultLeaderboard(lbName).RankMembers(context.Background(), membersAndScores)
+}
+
+// RankMembersBatch : Rank an array of members in chunks, reporting the outcome of every member.
+func RankMembersBatch(lbName string, membersAndScores []*RankScore, options BatchOptions) (*BatchReport, error) {
+	return defaultLeaderboard(lbName).RankMembersBatch(context.Background(), membersAndScores, options)
+}
+
+// RankMemberWithPolicy : Rank a member applying policy to the stored score.
+func RankMemberWithPolicy(lbName string, member string, score float64, policy UpdatePolicy) (*UpdateResult, error) {
+	return defaultLeaderboard(lbName).RankMemberWithPolicy(context.Background(), member, score, policy)
+}
+
+// RankMembersWithPolicy : Rank an array of members applying policy to the stored scores.
+func RankMembersWithPolicy(lbName string, membersAndScores []*RankScore, policy UpdatePolicy) ([]*UpdateResult, error) {
+	return defaultLeaderboard(lbName).RankMembersWithPolicy(context.Background(), membersAndScores, policy)
+}
+
+// RankMemberWithData : Rank a member in the leaderboard with member data.
+func RankMemberWithData(lbName string, member string, score float64, data string) error {
+	return defaultLeaderboard(lbName).RankMemberWithData(context.Background(), member, score, data)
+}
+
+// MemberDataFor : Retrieve the member data for a member in the leaderboard.
+func MemberDataFor(lbName string, member string) (string, error) {
+	return defaultLeaderboard(lbName).MemberDataFor(context.Background(), member)
+}
+
+// UpdateMemberData : Update the member data for a member in the leaderboard.
+func UpdateMemberData(lbName string, member string, data string) error {
+	return defaultLeaderboard(lbName).UpdateMemberData(context.Background(), member, data)
+}
+
+// RemoveMemberData : Remove the member data for a member in the leaderboard.
+func RemoveMemberData(lbName string, member string) error {
+	return defaultLeaderboard(lbName).RemoveMemberData(context.Background(), member)
 }
 
 // RemoveMember : Remove a member from the leaderboard.
 func RemoveMember(lbName string, member string) error {
-	_, err := conn.Do("ZREM", lbName, member)
-	return err
+	return defaultLeaderboard(lbName).RemoveMember(context.Background(), member)
 }
 
 // TotalMembers : Retrieve the total number of members in the leaderboard.
 func TotalMembers(lbName string) (int, error) {
-	count, err := redis.Int(conn.Do("ZCARD", lbName))
-	if err != nil {
-		return -1, err
-	}
-	return count, nil
+	return defaultLeaderboard(lbName).TotalMembers(context.Background())
 }
 
 // TotalPages : Retrieve the total number of pages in the leaderboard.
 func TotalPages(lbName string, pageSize int) int {
-	if pageSize < 1 {
-		pageSize = DEFAULT_PAGESIZE
-	}
-
-	totalMembers, _ := TotalMembers(lbName)
-	return int(math.Ceil(float64(totalMembers) / float64(pageSize)))
+	return defaultLeaderboard(lbName).TotalPages(context.Background(), pageSize)
 }
 
 // TotalMembersInScoreRange : Retrieve the total members in a given score range from the leaderboard.
-func TotalMembersInScoreRange(lbName string, minScore int, maxScore int) (int, error) {
-	count, err := redis.Int(conn.Do("ZCOUNT", lbName, minScore, maxScore))
-	if err != nil {
-		return -1, err
-	}
-	return count, nil
+func TotalMembersInScoreRange(lbName string, minScore float64, maxScore float64) (int, error) {
+	return defaultLeaderboard(lbName).TotalMembersInScoreRange(context.Background(), minScore, maxScore)
 }
 
 // ChangeScoreFor : Change the score for a member in the leaderboard by a score delta which can be positive or negative.
-func ChangeScoreFor(lbName string, member string, delta int) error {
-	_, err := conn.Do("ZINCRBY", lbName, delta, member)
-	return err
+func ChangeScoreFor(lbName string, member string, delta float64) error {
+	return defaultLeaderboard(lbName).ChangeScoreFor(context.Background(), member, delta)
 }
 
 // CheckMember : Check to see if a member exists in the leaderboard.
 func CheckMember(lbName string, member string) (bool, error) {
-	res, err := conn.Do("ZSCORE", lbName, member)
-	if err != nil {
-		return false, err
-	}
-	if res == nil {
-		return false, nil
-	}
-	return true, nil
+	return defaultLeaderboard(lbName).CheckMember(context.Background(), member)
 }
 
 // RankMemberEx :   Rank a member in the leaderboard.
-func RankMemberEx(lbName string, member string, score int) (int, error) {
-	// get current score
-	res, err := conn.Do("ZSCORE", lbName, member)
-	if err != nil {
-		return 0, err
-	}
-	if res == nil {
-		// not found exist score . add new score.
-		_, err := conn.Do("ZADD", lbName, score, member)
-		if err != nil {
-			return 0, err
-		}
-	} else {
-		existScore, _ := redis.Int(res, nil)
-		// compare new score.
-		if existScore != score {
-			newScore, err := redis.Int(conn.Do("ZINCRBY", lbName, score-existScore, member))
-			if err != nil {
-				return 0, err
-			}
-			if newScore != score {
-				return 0, fmt.Errorf("unexpected score %d,%d", newScore, score)
-			}
-		}
-	}
-
-	// get new rank..
-	param1 := "(" + strconv.Itoa(score)
-	param2 := "+inf"
-
-	rank, err := redis.Int(conn.Do("ZCOUNT", lbName, param1, param2))
-	if err != nil {
-		return 0, err
-	}
-
-	return rank + 1, nil
+func RankMemberEx(lbName string, member string, score float64) (int, error) {
+	return defaultLeaderboard(lbName).RankMemberEx(context.Background(), member, score)
 }
 
 // ScoreFor : Retrieve the score for a member in the leaderboard.
-func ScoreFor(lbName string, member string) (int, error) {
-	score, err := redis.Int(conn.Do("ZSCORE", lbName, member))
-	if err != nil {
-		return -1, err
-	}
-	return score, nil
+func ScoreFor(lbName string, member string) (float64, error) {
+	return defaultLeaderboard(lbName).ScoreFor(context.Background(), member)
 }
 
 // RankFor : Retrieve the rank for a member in the leaderboard.
 func RankFor(lbName string, member string) (int, error) {
-	score, err := redis.Int(conn.Do("ZSCORE", lbName, member))
-	if err != nil {
-		return -1, err
-	}
-
-	param1 := "(" + strconv.Itoa(score)
-	param2 := "+inf"
-
-	rank, err := redis.Int(conn.Do("ZCOUNT", lbName, param1, param2))
-	if err != nil {
-		return -1, err
-	}
-
-	return rank + 1, nil
+	return defaultLeaderboard(lbName).RankFor(context.Background(), member)
 }
 
 // ScoreAndRankFor : Retrieve the score and rank for a member in the leaderboard.
 func ScoreAndRankFor(lbName string, member string) (*RankScore, error) {
-	score, err := redis.Int(conn.Do("ZSCORE", lbName, member))
-	if err != nil {
-		return nil, err
-	}
-
-	param1 := "(" + strconv.Itoa(score)
-	param2 := "+inf"
-
-	rank, err := redis.Int(conn.Do("ZCOUNT", lbName, param1, param2))
-	if err != nil {
-		return nil, err
-	}
-
-	return &RankScore{Member: member, score: score, rank: rank + 1}, nil
+	return defaultLeaderboard(lbName).ScoreAndRankFor(context.Background(), member)
 }
 
 // RemoveMembersInScoreRange : Remove members from the leaderboard in a given score range.
-func RemoveMembersInScoreRange(lbName string, minScore int, maxScore int) error {
-	_, err := conn.Do("ZREMRANGEBYSCORE", lbName, minScore, maxScore)
-	return err
+func RemoveMembersInScoreRange(lbName string, minScore float64, maxScore float64) error {
+	return defaultLeaderboard(lbName).RemoveMembersInScoreRange(context.Background(), minScore, maxScore)
 }
 
 // RemoveMembersOutsideRank : Remove members from the leaderboard outside a given rank.
 func RemoveMembersOutsideRank(lbName string, rank int) (int, error) {
-	rankStart := 0
-	rankEnd := -(rank) - 1
+	return defaultLeaderboard(lbName).RemoveMembersOutsideRank(context.Background(), rank)
+}
 
-	count, err := redis.Int(conn.Do("ZREMRANGEBYRANK", lbName, rankStart, rankEnd))
-	if err != nil {
-		return -1, err
-	}
-	return count, nil
+// ExpireMember : Expire a member ttl from now. the member is removed by the next PruneExpired after that.
+// returns ErrMemberNotFound for a non-existent member.
+func ExpireMember(lbName string, member string, ttl time.Duration) error {
+	return defaultLeaderboard(lbName).ExpireMember(context.Background(), member, ttl)
+}
+
+// PruneExpired : Remove the members whose expiry has passed. returns the number of members removed.
+func PruneExpired(lbName string) (int, error) {
+	return defaultLeaderboard(lbName).PruneExpired(context.Background())
+}
+
+// PruneInactive : Remove the members not written for longer than idle. returns the number of members removed.
+func PruneInactive(lbName string, idle time.Duration) (int, error) {
+	return defaultLeaderboard(lbName).PruneInactive(context.Background(), idle)
 }
 
 // PercentileFor : Retrieve the percentile for a member in the leaderboard.
 // @param member [String] Member name.
-// @return the percentile for a member in the leaderboard. Return +nil+ for a non-existent member.
+// @return the percentile for a member in the leaderboard. Return ErrMemberNotFound for a non-existent member.
 func PercentileFor(lbName string, member string) (int, error) {
-	if ok, err := CheckMember(lbName, member); err != nil || ok == false {
-		return -1, err
-	}
-
-	count, err := redis.Int(conn.Do("ZCARD", lbName))
-	if err != nil {
-		return -1, err
-	}
-
-	rank, err := redis.Int(conn.Do("ZREVRANK", lbName, member))
-	if err != nil {
-		return -1, err
-	}
-
-	return int(math.Ceil(float64(count-rank-1) / float64(count) * 100.0)), nil
+	return defaultLeaderboard(lbName).PercentileFor(context.Background(), member)
 }
 
 // ScoreForPercentile : Calculate the score for a given percentile value in the leaderboard.
-func ScoreForPercentile(lbName string, percentile int) (int, error) {
-	if percentile < 0 || percentile > 100 {
-		return -1, nil
-	}
-
-	totalMembers, err := TotalMembers(lbName)
-	if err != nil || totalMembers < 1 {
-		return -1, err
-	}
-
-	index := float64((float64(totalMembers) - 1.0) * (float64(percentile) / 100.0))
-
-	values, err := redis.Strings(conn.Do("ZREVRANGE", lbName, math.Floor(index), math.Ceil(index), "WITHSCORES"))
-	if err != nil {
-		return -1, err
-	}
-	// Response format: ["Alice", "123", "Bob", "456"] (i.e. flat list, not member/score tuples)
-	lowScore, _ := strconv.Atoi(values[1])
-
-	if index == math.Floor(index) {
-		return lowScore, nil
-	}
-
-	interpolateFraction := int(index - math.Floor(index))
-	hiScore, _ := strconv.Atoi(values[3])
-
-	return lowScore + interpolateFraction*(hiScore-lowScore), nil
+// returns ErrInvalidPercentile outside 0..100 and ErrLeaderboardEmpty without members.
+func ScoreForPercentile(lbName string, percentile int) (float64, error) {
+	return defaultLeaderboard(lbName).ScoreForPercentile(context.Background(), percentile)
 }
 
 // PageFor : Determine the page where a member falls in the leaderboard.
 func PageFor(lbName string, member string, pageSize int) (int, error) {
-	if pageSize < 1 {
-		pageSize = DEFAULT_PAGESIZE
-	}
-
-	rank, err := RankFor(lbName, member)
-	if err != nil {
-		return -1, err
-	}
-
-	return int(math.Ceil(float64(rank) / float64(pageSize))), nil
+	return defaultLeaderboard(lbName).PageFor(context.Background(), member, pageSize)
 }
 
 // RankedInList : Retrieve a page of leaders from the leaderboard for a given list of members.
-func RankedInList(lbName string, members []string) []*RankScore {
-	var ranksForMembers []*RankScore
-
-	if len(members) == 0 {
-		return ranksForMembers
-	}
-
-	// Get Score
-	for _, member := range members {
-		memberScore := &RankScore{Member: member}
-
-		if score, err := redis.Int(conn.Do("ZSCORE", lbName, member)); err == nil {
-			memberScore.score = score
-		} else {
-			memberScore.score = -1
-		}
-		ranksForMembers = append(ranksForMembers, memberScore)
-	}
-
-	// Get Rank.and MemberData
-	for i, memberScore := range ranksForMembers {
-		if memberScore.score == -1 {
-			ranksForMembers[i].rank = -1
-			continue
-		}
-
-		param1 := "(" + strconv.Itoa(memberScore.score)
-		param2 := "+inf"
-
-		if rank, err := redis.Int(conn.Do("ZCOUNT", lbName, param1, param2)); err == nil {
-			ranksForMembers[i].rank = rank + 1
-		} else {
-			ranksForMembers[i].rank = -1
-		}
-	}
-
-	return ranksForMembers
+func RankedInList(lbName string, members []string) ([]*RankScore, error) {
+	return defaultLeaderboard(lbName).RankedInList(context.Background(), members)
 }
 
 // Members : Retrieve a page of Members from the leaderboard.
 func Members(lbName string, currentPage int, pageSize int) ([]*RankScore, error) {
-	if currentPage < 1 {
-		currentPage = 1
-	}
-	if pageSize < 1 {
-		pageSize = DEFAULT_PAGESIZE
-	}
-
-	if totalPage := TotalPages(lbName, pageSize); currentPage > totalPage {
-		currentPage = totalPage
-	}
-
-	indexForRedis := currentPage - 1
-
-	startingOffset := (indexForRedis * pageSize)
-	if startingOffset < 0 {
-		startingOffset = 0
-	}
-
-	endingOffset := (startingOffset + pageSize) - 1
+	return defaultLeaderboard(lbName).Members(context.Background(), currentPage, pageSize)
+}
 
-	members, err := redis.Strings(conn.Do("ZREVRANGE", lbName, startingOffset, endingOffset))
-	if err != nil {
-		return []*RankScore{}, err
-	}
-	return RankedInList(lbName, members), nil
+// MembersByCursor : Retrieve the page of members next to a cursor of a previous page. an empty cursor reads the first page.
+// returns ErrInvalidCursor for a cursor not made by this package.
+func MembersByCursor(lbName string, cursor string, pageSize int) (*CursorPage, error) {
+	return defaultLeaderboard(lbName).MembersByCursor(context.Background(), cursor, pageSize)
 }
 
 // AllMembers : Retrieve all Members from the leaderboard.
 func AllMembers(lbName string) ([]*RankScore, error) {
-
-	members, err := redis.Strings(conn.Do("ZREVRANGE", lbName, 0, -1))
-	if err != nil {
-		return []*RankScore{}, err
-	}
-	return RankedInList(lbName, members), nil
+	return defaultLeaderboard(lbName).AllMembers(context.Background())
 }
 
 // MembersFromScoreRange : Retrieve members from the leaderboard within a given score range.
-func MembersFromScoreRange(lbName string, minimumScore int, maximumScore int) ([]*RankScore, error) {
-
-	startScore := minimumScore
-	endScore := maximumScore
-
-	members, err := redis.Strings(conn.Do("ZREVRANGEBYSCORE", lbName, startScore, endScore))
-	if err != nil {
-		return []*RankScore{}, err
-	}
-
-	return RankedInList(lbName, members), nil
+// returns ErrInvalidRange when maximumScore is below minimumScore.
+func MembersFromScoreRange(lbName string, minimumScore float64, maximumScore float64) ([]*RankScore, error) {
+	return defaultLeaderboard(lbName).MembersFromScoreRange(context.Background(), minimumScore, maximumScore)
 }
 
 // MembersFromRankRange : Retrieve members from the leaderboard within a given rank range.
+// returns ErrInvalidRange when endingRank is before startingRank.
 func MembersFromRankRange(lbName string, startingRank int, endingRank int) ([]*RankScore, error) {
-
-	startingRank = startingRank - 1
-	if startingRank < 0 {
-		startingRank = 0
-	}
-	endingRank = endingRank - 1
-
-	totalMembers, _ := TotalMembers(lbName)
-	if endingRank > totalMembers {
-		endingRank = totalMembers - 1
-	}
-
-	members, err := redis.Strings(conn.Do("ZREVRANGE", lbName, startingRank, endingRank))
-	if err != nil {
-		return []*RankScore{}, err
-	}
-
-	return RankedInList(lbName, members), nil
+	return defaultLeaderboard(lbName).MembersFromRankRange(context.Background(), startingRank, endingRank)
 }
 
 // Top : Retrieve members from the leaderboard within a range from 1 to the number given.
 func Top(lbName string, number int) ([]*RankScore, error) {
-	return MembersFromRankRange(lbName, 1, number)
+	return defaultLeaderboard(lbName).Top(context.Background(), number)
 }
 
 // MemberAt : Retrieve a member at the specified index from the leaderboard.
+// returns ErrLeaderboardEmpty without members and ErrInvalidRange for a position outside 1..TotalMembers.
 func MemberAt(lbName string, position int) (*RankScore, error) {
-	members, err := MembersFromRankRange(lbName, position, position)
-	if err != nil {
-		return nil, err
-	}
-	if len(members) >= 1 {
-		return members[0], nil
-	}
-	return nil, nil
+	return defaultLeaderboard(lbName).MemberAt(context.Background(), position)
 }
 
 // AroundMe : Retrieve a page of leaders from the leaderboard around a given member.
 func AroundMe(lbName string, member string, pageSize int) ([]*RankScore, error) {
+	return defaultLeaderboard(lbName).AroundMe(context.Background(), member, pageSize)
+}
 
-	if pageSize < 1 {
-		pageSize = DEFAULT_PAGESIZE
-	}
-
-	rank, err := redis.Int(conn.Do("ZREVRANK", lbName, member))
-	if err != nil {
-		return []*RankScore{}, err
-	}
+// DeleteLeaderboard : Delete the current leaderboard.
+func DeleteLeaderboard(lbName string) error {
+	return defaultLeaderboard(lbName).Delete(context.Background())
+}
 
-	startingOffset := rank - (pageSize / 2)
-	if startingOffset < 0 {
-		startingOffset = 0
-	}
-	endingOffset := (startingOffset + pageSize) - 1
+// Rollover : End the current season and archive the leaderboard under seasonID.
+func Rollover(lbName string, seasonID string) (*Season, error) {
+	return defaultLeaderboard(lbName).Rollover(context.Background(), seasonID)
+}
 
-	members, err := redis.Strings(conn.Do("ZREVRANGE", lbName, startingOffset, endingOffset))
-	if err != nil {
-		return []*RankScore{}, err
-	}
+// Seasons : Retrieve the past seasons of the leaderboard.
+func Seasons(lbName string) ([]*Season, error) {
+	return defaultLeaderboard(lbName).Seasons(context.Background())
+}
 
-	return RankedInList(lbName, members), nil
+// UnionOf : Replace the leaderboard with the union of the sources and their combined scores.
+func UnionOf(lbName string, sources []string, combine Combine) (int, error) {
+	return defaultLeaderboard(lbName).UnionOf(context.Background(), defaultLeaderboards(sources), combine)
 }
 
-// DeleteLeaderboard : Delete the current leaderboard.
-func DeleteLeaderboard(lbName string) error {
-	_, err := conn.Do("DEL", lbName)
-	return err
+// InterOf : Replace the leaderboard with the intersection of the sources and their combined scores.
+func InterOf(lbName string, sources []string, combine Combine) (int, error) {
+	return defaultLeaderboard(lbName).InterOf(context.Background(), defaultLeaderboards(sources), combine)
 }
@@ -23,7 +23,7 @@ func rankMembersInLeaderboard(membersToAdd int) error {
 
 	for i := 1; i < membersToAdd+1; i++ {
 		member := "member_" + strconv.Itoa(i)
-		if err := RankMember(lbName, member, i); err != nil {
+		if err := RankMember(lbName, member, float64(i)); err != nil {
 			return fmt.Errorf("leaderboard.RankMember %d err=%s", i, err)
 		}
 	}
@@ -234,7 +234,7 @@ func TestTotalMembers(t *testing.T) {
 		t.Error("NewLeaderBoard Top Err! ", members)
 	}
 
-	rankedMembers := RankedInList(lbName, []string{"member_1", "member_5", "member_10"})
+	rankedMembers, _ := RankedInList(lbName, []string{"member_1", "member_5", "member_10"})
 	if len(rankedMembers) != 3 {
 		t.Error("NewLeaderBoard RankedInList Err! ", len(rankedMembers))
 	}
@@ -286,11 +286,11 @@ func TestTotalMembers(t *testing.T) {
 		}
 	*/
 
-	rankedMembers = RankedInList(lbName, []string{"member_1", "member_5", "jones"})
+	rankedMembers, _ = RankedInList(lbName, []string{"member_1", "member_5", "jones"})
 	if len(rankedMembers) != 3 {
 		t.Error("NewLeaderBoard RankedInList Err! ", len(rankedMembers))
 	}
-	if rankedMembers[2].rank != -1 {
+	if rankedMembers[2].GetErr() != ErrMemberNotFound || rankedMembers[1].GetErr() != nil {
 		t.Error("NewLeaderBoard RankedInList Err! ", rankedMembers[2])
 	}
 
@@ -0,0 +1,387 @@
+// Package rankgrpc : grpc server of the leaderboard service of package rankpb, over the leaderboards of a rank.Backend.
+// clients use rankpb.NewLeaderboardClient.
+// names of the keys a leaderboard keeps next to its sorted set are rejected with codes.InvalidArgument, see rank.ValidName.
+package rankgrpc
+
+import (
+	"context"
+	"errors"
+
+	rank "github.com/jaksal/leaderboard"
+	"github.com/jaksal/leaderboard/rankpb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Server : rankpb.LeaderboardServer serving the leaderboards stored in a backend.
+type Server struct {
+	rankpb.UnimplementedLeaderboardServer
+	backend rank.Backend
+	options []rank.Option
+}
+
+// NewServer : create a server for the leaderboards stored in backend, opened with options.
+// the leaderboards also publish their events on their channel, for WatchTop.
+func NewServer(backend rank.Backend, options ...rank.Option) *Server {
+	return &Server{backend: backend, options: append(options[:len(options):len(options)], rank.WithEventChannel())}
+}
+
+// leaderboard : leaderboard named name, which must pass rank.ValidName.
+func (s *Server) leaderboard(name string) (*rank.Leaderboard, error) {
+	if err := rank.ValidName(name); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "leaderboard: "+err.Error())
+	}
+	return rank.NewLeaderboardWithBackend(s.backend, name, s.options...), nil
+}
+
+// statusOf : grpc status of an error of package rank.
+func statusOf(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, rank.ErrMemberNotFound), errors.Is(err, rank.ErrLeaderboardEmpty):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, rank.ErrInvalidRange), errors.Is(err, rank.ErrInvalidPercentile):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+var policies = map[rankpb.UpdatePolicy]rank.UpdatePolicy{
+	rankpb.UpdatePolicy_UPDATE_POLICY_REPLACE:   rank.PolicyReplace,
+	rankpb.UpdatePolicy_UPDATE_POLICY_INCREMENT: rank.PolicyIncrement,
+	rankpb.UpdatePolicy_UPDATE_POLICY_MAX:       rank.PolicyMax,
+	rankpb.UpdatePolicy_UPDATE_POLICY_MIN:       rank.PolicyMin,
+}
+
+func standingOf(m *rank.RankScore) *rankpb.Standing {
+	return &rankpb.Standing{Member: m.Member, Score: m.GetScore(), Rank: int32(m.GetRank()), Data: m.GetData()}
+}
+
+func pageOf(members []*rank.RankScore) *rankpb.Page {
+	page := &rankpb.Page{Members: make([]*rankpb.Standing, 0, len(members))}
+	for _, m := range members {
+		page.Members = append(page.Members, standingOf(m))
+	}
+	return page
+}
+
+func pageSizeOf(pageSize int32) (int, error) {
+	if pageSize < 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "page_size %d: must not be negative", pageSize)
+	}
+	if pageSize == 0 {
+		return rank.DEFAULT_PAGESIZE, nil
+	}
+	return int(pageSize), nil
+}
+
+// scoreAndRank : standing of member, NOT_FOUND when missing.
+func scoreAndRank(ctx context.Context, lb *rank.Leaderboard, member string) (*rankpb.Standing, error) {
+	m, err := lb.ScoreAndRankFor(ctx, member)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	return standingOf(m), nil
+}
+
+func (s *Server) RankMember(ctx context.Context, req *rankpb.RankMemberRequest) (*rankpb.Standing, error) {
+	lb, err := s.leaderboard(req.Leaderboard)
+	if err != nil {
+		return nil, err
+	}
+	if req.Member == "" {
+		return nil, status.Error(codes.InvalidArgument, "member: empty name")
+	}
+
+	if req.Policy == rankpb.UpdatePolicy_UPDATE_POLICY_UNSPECIFIED {
+		if err := lb.RankMember(ctx, req.Member, req.Score); err != nil {
+			return nil, statusOf(err)
+		}
+		return scoreAndRank(ctx, lb, req.Member)
+	}
+
+	policy, ok := policies[req.Policy]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "policy: unknown %v", req.Policy)
+	}
+	res, err := lb.RankMemberWithPolicy(ctx, req.Member, req.Score, policy)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	return standingOf(&res.RankScore), nil
+}
+
+func (s *Server) RankMembers(ctx context.Context, req *rankpb.RankMembersRequest) (*emptypb.Empty, error) {
+	lb, err := s.leaderboard(req.Leaderboard)
+	if err != nil {
+		return nil, err
+	}
+	membersAndScores := make([]*rank.RankScore, 0, len(req.Members))
+	for _, m := range req.Members {
+		if m.Member == "" {
+			return nil, status.Error(codes.InvalidArgument, "members: empty name")
+		}
+		membersAndScores = append(membersAndScores, rank.NewRankScore(m.Member, m.Score))
+	}
+	if len(membersAndScores) > 0 {
+		if err := lb.RankMembers(ctx, membersAndScores); err != nil {
+			return nil, statusOf(err)
+		}
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ChangeScore(ctx context.Context, req *rankpb.ChangeScoreRequest) (*rankpb.Standing, error) {
+	lb, err := s.leaderboard(req.Leaderboard)
+	if err != nil {
+		return nil, err
+	}
+	if req.Member == "" {
+		return nil, status.Error(codes.InvalidArgument, "member: empty name")
+	}
+	if err := lb.ChangeScoreFor(ctx, req.Member, req.Delta); err != nil {
+		return nil, statusOf(err)
+	}
+	return scoreAndRank(ctx, lb, req.Member)
+}
+
+func (s *Server) RemoveMember(ctx context.Context, req *rankpb.MemberRequest) (*emptypb.Empty, error) {
+	lb, err := s.leaderboard(req.Leaderboard)
+	if err != nil {
+		return nil, err
+	}
+	ok, err := lb.CheckMember(ctx, req.Member)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	if !ok {
+		return nil, statusOf(rank.ErrMemberNotFound)
+	}
+	if err := lb.RemoveMember(ctx, req.Member); err != nil {
+		return nil, statusOf(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ScoreAndRank(ctx context.Context, req *rankpb.MemberRequest) (*rankpb.Standing, error) {
+	lb, err := s.leaderboard(req.Leaderboard)
+	if err != nil {
+		return nil, err
+	}
+	return scoreAndRank(ctx, lb, req.Member)
+}
+
+func (s *Server) Percentile(ctx context.Context, req *rankpb.MemberRequest) (*rankpb.PercentileResponse, error) {
+	lb, err := s.leaderboard(req.Leaderboard)
+	if err != nil {
+		return nil, err
+	}
+	percentile, err := lb.PercentileFor(ctx, req.Member)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	return &rankpb.PercentileResponse{Percentile: int32(percentile)}, nil
+}
+
+func (s *Server) TotalMembers(ctx context.Context, req *rankpb.LeaderboardRequest) (*rankpb.TotalMembersResponse, error) {
+	lb, err := s.leaderboard(req.Leaderboard)
+	if err != nil {
+		return nil, err
+	}
+	total, err := lb.TotalMembers(ctx)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	return &rankpb.TotalMembersResponse{Total: int32(total)}, nil
+}
+
+func (s *Server) Members(ctx context.Context, req *rankpb.MembersRequest) (*rankpb.Page, error) {
+	lb, err := s.leaderboard(req.Leaderboard)
+	if err != nil {
+		return nil, err
+	}
+	pageSize, err := pageSizeOf(req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	current := int(req.Page)
+	if current == 0 {
+		current = 1
+	}
+	if current < 1 {
+		return nil, status.Errorf(codes.InvalidArgument, "page %d: must be at least 1", req.Page)
+	}
+
+	total, err := lb.TotalMembers(ctx)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	members := []*rank.RankScore{}
+	if (current-1)*pageSize < total {
+		if members, err = lb.Members(ctx, current, pageSize); err != nil {
+			return nil, statusOf(err)
+		}
+	}
+
+	page := pageOf(members)
+	page.Total, page.Page, page.PageSize = int32(total), int32(current), int32(pageSize)
+	return page, nil
+}
+
+func (s *Server) Top(ctx context.Context, req *rankpb.TopRequest) (*rankpb.Page, error) {
+	lb, err := s.leaderboard(req.Leaderboard)
+	if err != nil {
+		return nil, err
+	}
+	if req.N < 1 {
+		return nil, status.Errorf(codes.InvalidArgument, "n %d: must be at least 1", req.N)
+	}
+	members, err := lb.Top(ctx, int(req.N))
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	return pageOf(members), nil
+}
+
+func (s *Server) RankRange(ctx context.Context, req *rankpb.RankRangeRequest) (*rankpb.Page, error) {
+	lb, err := s.leaderboard(req.Leaderboard)
+	if err != nil {
+		return nil, err
+	}
+	if req.From < 1 || req.To < req.From {
+		return nil, status.Errorf(codes.InvalidArgument, "ranks %d..%d: from must be at least 1 and to at least from", req.From, req.To)
+	}
+	members, err := lb.MembersFromRankRange(ctx, int(req.From), int(req.To))
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	return pageOf(members), nil
+}
+
+func (s *Server) ScoreRange(ctx context.Context, req *rankpb.ScoreRangeRequest) (*rankpb.Page, error) {
+	lb, err := s.leaderboard(req.Leaderboard)
+	if err != nil {
+		return nil, err
+	}
+	members, err := lb.MembersFromScoreRange(ctx, req.Min, req.Max)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	return pageOf(members), nil
+}
+
+func (s *Server) AroundMe(ctx context.Context, req *rankpb.AroundMeRequest) (*rankpb.Page, error) {
+	lb, err := s.leaderboard(req.Leaderboard)
+	if err != nil {
+		return nil, err
+	}
+	pageSize, err := pageSizeOf(req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	members, err := lb.AroundMe(ctx, req.Member, pageSize)
+	if err != nil {
+		return nil, statusOf(err)
+	}
+	return pageOf(members), nil
+}
+
+func (s *Server) Delete(ctx context.Context, req *rankpb.LeaderboardRequest) (*emptypb.Empty, error) {
+	lb, err := s.leaderboard(req.Leaderboard)
+	if err != nil {
+		return nil, err
+	}
+	if err := lb.Delete(ctx); err != nil {
+		return nil, statusOf(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// WatchTop : send the top n, then again after each event of the leaderboard that moves a member in or
+// out of it. only writes that publish events are seen, which the writes through a Server do.
+func (s *Server) WatchTop(req *rankpb.TopRequest, stream rankpb.Leaderboard_WatchTopServer) error {
+	lb, err := s.leaderboard(req.Leaderboard)
+	if err != nil {
+		return err
+	}
+	if req.N < 1 {
+		return status.Errorf(codes.InvalidArgument, "n %d: must be at least 1", req.N)
+	}
+
+	ctx := stream.Context()
+	events, err := lb.Subscribe(ctx)
+	if err != nil {
+		return statusOf(err)
+	}
+
+	var last *rankpb.Page
+	send := func() error {
+		members, err := lb.Top(ctx, int(req.N))
+		if err != nil {
+			return statusOf(err)
+		}
+		page := pageOf(members)
+		if last != nil && samePage(last, page) {
+			return nil
+		}
+		last = page
+		return stream.Send(page)
+	}
+	if err := send(); err != nil {
+		return err
+	}
+
+	inTop := func(rank int) bool {
+		return rank > 0 && rank <= int(req.N)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			changed := inTop(event.OldRank) || inTop(event.NewRank)
+			// read the top once for a burst of events.
+			for pending := true; pending; {
+				select {
+				case event, ok := <-events:
+					if !ok {
+						return nil
+					}
+					changed = changed || inTop(event.OldRank) || inTop(event.NewRank)
+				default:
+					pending = false
+				}
+			}
+			if !changed {
+				continue
+			}
+			if err := send(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// samePage : a and b hold the same standings.
+func samePage(a *rankpb.Page, b *rankpb.Page) bool {
+	if len(a.Members) != len(b.Members) {
+		return false
+	}
+	for i := range a.Members {
+		x, y := a.Members[i], b.Members[i]
+		if x.Member != y.Member || x.Score != y.Score || x.Rank != y.Rank || x.Data != y.Data {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,169 @@
+package rankgrpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	rank "github.com/jaksal/leaderboard"
+	"github.com/jaksal/leaderboard/rankpb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const lbName = "test_grpc"
+
+func newClient(t *testing.T) rankpb.LeaderboardClient {
+	lis := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	rankpb.RegisterLeaderboardServer(server, NewServer(rank.NewMemoryBackend()))
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal("Server Dial Err!", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return rankpb.NewLeaderboardClient(conn)
+}
+
+func TestServer(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	ctx := context.Background()
+	client := newClient(t)
+
+	if res, err := client.RankMember(ctx, &rankpb.RankMemberRequest{Leaderboard: lbName, Member: "member_1", Score: 10}); err != nil || res.Rank != 1 {
+		t.Error("Server RankMember Err!", res, err)
+	}
+	if _, err := client.RankMembers(ctx, &rankpb.RankMembersRequest{Leaderboard: lbName, Members: []*rankpb.RankMembersRequest_Entry{{Member: "member_2", Score: 20}, {Member: "member_3", Score: 30}}}); err != nil {
+		t.Error("Server RankMembers Err!", err)
+	}
+	if res, err := client.ChangeScore(ctx, &rankpb.ChangeScoreRequest{Leaderboard: lbName, Member: "member_1", Delta: 15}); err != nil || res.Score != 25 || res.Rank != 2 {
+		t.Error("Server ChangeScore Err!", res, err)
+	}
+	if res, err := client.RankMember(ctx, &rankpb.RankMemberRequest{Leaderboard: lbName, Member: "member_1", Score: 5, Policy: rankpb.UpdatePolicy_UPDATE_POLICY_MAX}); err != nil || res.Score != 25 {
+		t.Error("Server RankMember policy Err!", res, err)
+	}
+	if res, err := client.ScoreAndRank(ctx, &rankpb.MemberRequest{Leaderboard: lbName, Member: "member_3"}); err != nil || res.Rank != 1 || res.Score != 30 {
+		t.Error("Server ScoreAndRank Err!", res, err)
+	}
+	if res, err := client.TotalMembers(ctx, &rankpb.LeaderboardRequest{Leaderboard: lbName}); err != nil || res.Total != 3 {
+		t.Error("Server TotalMembers Err!", res, err)
+	}
+	if res, err := client.Members(ctx, &rankpb.MembersRequest{Leaderboard: lbName, Page: 2, PageSize: 2}); err != nil || res.Total != 3 || len(res.Members) != 1 || res.Members[0].Member != "member_2" {
+		t.Error("Server Members Err!", res, err)
+	}
+	if res, err := client.Top(ctx, &rankpb.TopRequest{Leaderboard: lbName, N: 2}); err != nil || len(res.Members) != 2 || res.Members[1].Member != "member_1" {
+		t.Error("Server Top Err!", res, err)
+	}
+	if res, err := client.RankRange(ctx, &rankpb.RankRangeRequest{Leaderboard: lbName, From: 2, To: 3}); err != nil || len(res.Members) != 2 {
+		t.Error("Server RankRange Err!", res, err)
+	}
+	if res, err := client.ScoreRange(ctx, &rankpb.ScoreRangeRequest{Leaderboard: lbName, Min: 20, Max: 25}); err != nil || len(res.Members) != 2 {
+		t.Error("Server ScoreRange Err!", res, err)
+	}
+	if res, err := client.AroundMe(ctx, &rankpb.AroundMeRequest{Leaderboard: lbName, Member: "member_1", PageSize: 3}); err != nil || len(res.Members) != 3 {
+		t.Error("Server AroundMe Err!", res, err)
+	}
+	if res, err := client.Percentile(ctx, &rankpb.MemberRequest{Leaderboard: lbName, Member: "member_3"}); err != nil || res.Percentile != 67 {
+		t.Error("Server Percentile Err!", res, err)
+	}
+
+	// errors.
+	for name, call := range map[string]func() error{
+		"ScoreAndRank": func() error {
+			_, err := client.ScoreAndRank(ctx, &rankpb.MemberRequest{Leaderboard: lbName, Member: "unknown"})
+			return err
+		},
+		"Percentile": func() error {
+			_, err := client.Percentile(ctx, &rankpb.MemberRequest{Leaderboard: lbName, Member: "unknown"})
+			return err
+		},
+		"AroundMe": func() error {
+			_, err := client.AroundMe(ctx, &rankpb.AroundMeRequest{Leaderboard: lbName, Member: "unknown"})
+			return err
+		},
+		"RemoveMember": func() error {
+			_, err := client.RemoveMember(ctx, &rankpb.MemberRequest{Leaderboard: lbName, Member: "unknown"})
+			return err
+		},
+	} {
+		if status.Code(call()) != codes.NotFound {
+			t.Error("Server "+name+" NotFound Err!", call())
+		}
+	}
+	if _, err := client.RankRange(ctx, &rankpb.RankRangeRequest{Leaderboard: lbName, From: 3, To: 1}); status.Code(err) != codes.InvalidArgument {
+		t.Error("Server RankRange InvalidArgument Err!", err)
+	}
+	if _, err := client.ScoreRange(ctx, &rankpb.ScoreRangeRequest{Leaderboard: lbName, Min: 30, Max: 10}); status.Code(err) != codes.InvalidArgument {
+		t.Error("Server ScoreRange InvalidArgument Err!", err)
+	}
+	if _, err := client.Top(ctx, &rankpb.TopRequest{Leaderboard: ""}); status.Code(err) != codes.InvalidArgument {
+		t.Error("Server Top InvalidArgument Err!", err)
+	}
+
+	if _, err := client.RemoveMember(ctx, &rankpb.MemberRequest{Leaderboard: lbName, Member: "member_1"}); err != nil {
+		t.Error("Server RemoveMember Err!", err)
+	}
+	if _, err := client.Delete(ctx, &rankpb.LeaderboardRequest{Leaderboard: lbName}); err != nil {
+		t.Error("Server Delete Err!", err)
+	}
+	if res, _ := client.TotalMembers(ctx, &rankpb.LeaderboardRequest{Leaderboard: lbName}); res.GetTotal() != 0 {
+		t.Error("Server Delete Err!", res)
+	}
+}
+
+func TestServerWatchTop(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client := newClient(t)
+
+	client.RankMember(ctx, &rankpb.RankMemberRequest{Leaderboard: lbName, Member: "member_1", Score: 10})
+	stream, err := client.WatchTop(ctx, &rankpb.TopRequest{Leaderboard: lbName, N: 2})
+	if err != nil {
+		t.Fatal("Server WatchTop Err!", err)
+	}
+	page, err := stream.Recv()
+	if err != nil || len(page.Members) != 1 {
+		t.Fatal("Server WatchTop Err!", page, err)
+	}
+
+	// the stream is subscribed once the first page is sent.
+	client.RankMember(ctx, &rankpb.RankMemberRequest{Leaderboard: lbName, Member: "member_2", Score: 20})
+	if page, err := stream.Recv(); err != nil || len(page.Members) != 2 || page.Members[0].Member != "member_2" {
+		t.Error("Server WatchTop Err!", page, err)
+	}
+	// a member outside the top 2 does not send a page.
+	client.RankMember(ctx, &rankpb.RankMemberRequest{Leaderboard: lbName, Member: "member_3", Score: 5})
+	client.RankMember(ctx, &rankpb.RankMemberRequest{Leaderboard: lbName, Member: "member_3", Score: 15})
+	if page, err := stream.Recv(); err != nil || len(page.Members) != 2 || page.Members[1].Member != "member_3" {
+		t.Error("Server WatchTop Err!", page, err)
+	}
+}
+
+func TestServerNames(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	ctx := context.Background()
+	client := newClient(t)
+
+	for _, name := range []string{lbName + ":data", lbName + ":ts", lbName + ":top:10", lbName + ":season:2024-s1", lbName + ":seasons"} {
+		if _, err := client.RankMember(ctx, &rankpb.RankMemberRequest{Leaderboard: name, Member: "member_1", Score: 10}); status.Code(err) != codes.InvalidArgument {
+			t.Error("Server ValidName Err!", name, err)
+		}
+		if _, err := client.Delete(ctx, &rankpb.LeaderboardRequest{Leaderboard: name}); status.Code(err) != codes.InvalidArgument {
+			t.Error("Server ValidName Err!", name, err)
+		}
+	}
+	if _, err := client.TotalMembers(ctx, &rankpb.LeaderboardRequest{Leaderboard: lbName + ":topscores"}); err != nil {
+		t.Error("Server ValidName Err!", err)
+	}
+}
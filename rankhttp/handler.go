@@ -0,0 +1,440 @@
+// Package rankhttp : net/http handler exposing the leaderboards of a rank.Backend as JSON endpoints.
+//
+//	GET    /leaderboards/{name}?page=1&page_size=25        page of members
+//	GET    /leaderboards/{name}?cursor=&page_size=25       page of members from the next or prev cursor of a page
+//	DELETE /leaderboards/{name}                             delete the leaderboard
+//	GET    /leaderboards/{name}/top?n=10                    top n members
+//	GET    /leaderboards/{name}/ranks?from=1&to=10          members ranked from..to
+//	GET    /leaderboards/{name}/scores?min=0&max=100        members scored min..max
+//	POST   /leaderboards/{name}/members                     rank members {"members":[{"member":"a","score":1}]}
+//	GET    /leaderboards/{name}/members/{member}            score and rank of member
+//	PUT    /leaderboards/{name}/members/{member}            rank member {"score":1,"policy":"max"}
+//	DELETE /leaderboards/{name}/members/{member}            remove member
+//	POST   /leaderboards/{name}/members/{member}/score      change the score of member {"delta":1}
+//	GET    /leaderboards/{name}/members/{member}/around     page around member ?page_size=25
+//	GET    /leaderboards/{name}/members/{member}/percentile percentile of member
+//
+// errors are {"error":{"code":"member_not_found","message":"..."}} with the status of the code.
+// names of the keys a leaderboard keeps next to its sorted set are rejected, see rank.ValidName.
+package rankhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	rank "github.com/jaksal/leaderboard"
+)
+
+// error codes of the responses.
+const (
+	// CodeMemberNotFound : 404, the member is not in the leaderboard.
+	CodeMemberNotFound = "member_not_found"
+	// CodeLeaderboardEmpty : 404, the leaderboard has no member to answer from.
+	CodeLeaderboardEmpty = "leaderboard_empty"
+	// CodeInvalidRange : 400, a page, rank or score range out of bounds.
+	CodeInvalidRange = "invalid_range"
+	// CodeInvalidRequest : 400, a malformed parameter or body.
+	CodeInvalidRequest = "invalid_request"
+	// CodeBodyTooLarge : 413, a request body over MaxBodySize.
+	CodeBodyTooLarge = "body_too_large"
+	// CodeInternal : 500, the backend failed.
+	CodeInternal = "internal"
+)
+
+// Error : error body of a response.
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+// status : http status of an error code.
+func (e *Error) status() int {
+	switch e.Code {
+	case CodeMemberNotFound, CodeLeaderboardEmpty:
+		return http.StatusNotFound
+	case CodeInvalidRange, CodeInvalidRequest:
+		return http.StatusBadRequest
+	case CodeBodyTooLarge:
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusInternalServerError
+}
+
+// Standing : member of a response.
+type Standing struct {
+	Member string  `json:"member"`
+	Score  float64 `json:"score"`
+	Rank   int     `json:"rank"`
+	Data   string  `json:"data,omitempty"`
+}
+
+// Page : members of a response.
+type Page struct {
+	Members []*Standing `json:"members"`
+	// Total, Page, PageSize : set for the pages of the leaderboard.
+	Total    int `json:"total,omitempty"`
+	Page     int `json:"page,omitempty"`
+	PageSize int `json:"page_size,omitempty"`
+	// Next, Prev : cursors of the pages read with a cursor. empty at the ends.
+	Next string `json:"next,omitempty"`
+	Prev string `json:"prev,omitempty"`
+}
+
+// RankRequest : body of PUT /leaderboards/{name}/members/{member}.
+type RankRequest struct {
+	Score float64 `json:"score"`
+	// Policy : rank.UpdatePolicy. empty always stores the score.
+	Policy rank.UpdatePolicy `json:"policy,omitempty"`
+}
+
+// RankMembersRequest : body of POST /leaderboards/{name}/members.
+type RankMembersRequest struct {
+	Members []struct {
+		Member string  `json:"member"`
+		Score  float64 `json:"score"`
+	} `json:"members"`
+}
+
+// ChangeScoreRequest : body of POST /leaderboards/{name}/members/{member}/score.
+type ChangeScoreRequest struct {
+	Delta float64 `json:"delta"`
+}
+
+// MaxBodySize : largest request body read, in bytes.
+const MaxBodySize = 1 << 20
+
+// Handler : http.Handler serving the leaderboards stored in a backend.
+type Handler struct {
+	backend   rank.Backend
+	options   []rank.Option
+	validName func(name string) error
+	mux       *http.ServeMux
+}
+
+// NewHandler : create a handler for the leaderboards stored in backend, opened with options.
+// leaderboard names are checked with rank.ValidName.
+func NewHandler(backend rank.Backend, options ...rank.Option) *Handler {
+	h := &Handler{backend: backend, options: options, validName: rank.ValidName, mux: http.NewServeMux()}
+	h.handle("GET /leaderboards/{name}", h.members)
+	h.handle("DELETE /leaderboards/{name}", h.delete)
+	h.handle("GET /leaderboards/{name}/top", h.top)
+	h.handle("GET /leaderboards/{name}/ranks", h.rankRange)
+	h.handle("GET /leaderboards/{name}/scores", h.scoreRange)
+	h.handle("POST /leaderboards/{name}/members", h.rankMembers)
+	h.handle("GET /leaderboards/{name}/members/{member}", h.member)
+	h.handle("PUT /leaderboards/{name}/members/{member}", h.rankMember)
+	h.handle("DELETE /leaderboards/{name}/members/{member}", h.removeMember)
+	h.handle("POST /leaderboards/{name}/members/{member}/score", h.changeScore)
+	h.handle("GET /leaderboards/{name}/members/{member}/around", h.aroundMe)
+	h.handle("GET /leaderboards/{name}/members/{member}/percentile", h.percentile)
+	return h
+}
+
+// WithNameValidator : check the leaderboard names of the requests with fn instead of rank.ValidName, e.g. an allow-list.
+// a name fn returns an error for is rejected with CodeInvalidRequest.
+func (h *Handler) WithNameValidator(fn func(name string) error) *Handler {
+	h.validName = fn
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// handle : route pattern to fn, writing its result or its error as JSON.
+func (h *Handler) handle(pattern string, fn func(r *http.Request, lb *rank.Leaderboard) (interface{}, error)) {
+	h.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("name")
+		if err := h.validName(name); err != nil {
+			writeError(w, invalidRequest("name: %v", err))
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, MaxBodySize)
+		lb := rank.NewLeaderboardWithBackend(h.backend, name, h.options...)
+		res, err := fn(r, lb)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		if res == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeJSON(w, http.StatusOK, res)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	var e *Error
+	switch {
+	case errors.As(err, &e):
+	case errors.Is(err, rank.ErrMemberNotFound):
+		e = &Error{Code: CodeMemberNotFound, Message: err.Error()}
+	case errors.Is(err, rank.ErrLeaderboardEmpty):
+		e = &Error{Code: CodeLeaderboardEmpty, Message: err.Error()}
+	case errors.Is(err, rank.ErrInvalidRange), errors.Is(err, rank.ErrInvalidPercentile):
+		e = &Error{Code: CodeInvalidRange, Message: err.Error()}
+	case errors.Is(err, rank.ErrInvalidCursor):
+		e = &Error{Code: CodeInvalidRequest, Message: err.Error()}
+	default:
+		e = &Error{Code: CodeInternal, Message: err.Error()}
+	}
+	writeJSON(w, e.status(), map[string]*Error{"error": e})
+}
+
+func invalidRange(format string, args ...interface{}) error {
+	return &Error{Code: CodeInvalidRange, Message: fmt.Sprintf(format, args...)}
+}
+
+func invalidRequest(format string, args ...interface{}) error {
+	return &Error{Code: CodeInvalidRequest, Message: fmt.Sprintf(format, args...)}
+}
+
+// intParam : query parameter name as an int, def when missing.
+func intParam(r *http.Request, name string, def int) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, invalidRequest("%s: %q is not an integer", name, value)
+	}
+	return n, nil
+}
+
+// floatParam : query parameter name as a finite float, required.
+func floatParam(r *http.Request, name string) (float64, error) {
+	value := r.URL.Query().Get(name)
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, invalidRequest("%s: %q is not a finite number", name, value)
+	}
+	return f, nil
+}
+
+func decodeBody(r *http.Request, v interface{}) error {
+	err := json.NewDecoder(r.Body).Decode(v)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return &Error{Code: CodeBodyTooLarge, Message: fmt.Sprintf("body: larger than %d bytes", tooLarge.Limit)}
+	}
+	if err != nil {
+		return invalidRequest("body: %v", err)
+	}
+	return nil
+}
+
+func standingOf(m *rank.RankScore) *Standing {
+	return &Standing{Member: m.Member, Score: m.GetScore(), Rank: m.GetRank(), Data: m.GetData()}
+}
+
+func pageOf(members []*rank.RankScore) *Page {
+	page := &Page{Members: make([]*Standing, 0, len(members))}
+	for _, m := range members {
+		page.Members = append(page.Members, standingOf(m))
+	}
+	return page
+}
+
+func (h *Handler) members(r *http.Request, lb *rank.Leaderboard) (interface{}, error) {
+	current, err := intParam(r, "page", 1)
+	if err != nil {
+		return nil, err
+	}
+	pageSize, err := intParam(r, "page_size", rank.DEFAULT_PAGESIZE)
+	if err != nil {
+		return nil, err
+	}
+	if current < 1 || pageSize < 1 {
+		return nil, invalidRange("page %d page_size %d: must be at least 1", current, pageSize)
+	}
+	if r.URL.Query().Has("cursor") {
+		res, err := lb.MembersByCursor(r.Context(), r.URL.Query().Get("cursor"), pageSize)
+		if err != nil {
+			return nil, err
+		}
+		page := pageOf(res.Members)
+		page.PageSize, page.Next, page.Prev = pageSize, res.Next, res.Prev
+		return page, nil
+	}
+
+	total, err := lb.TotalMembers(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	members := []*rank.RankScore{}
+	if (current-1)*pageSize < total {
+		if members, err = lb.Members(r.Context(), current, pageSize); err != nil {
+			return nil, err
+		}
+	}
+
+	page := pageOf(members)
+	page.Total, page.Page, page.PageSize = total, current, pageSize
+	return page, nil
+}
+
+func (h *Handler) delete(r *http.Request, lb *rank.Leaderboard) (interface{}, error) {
+	return nil, lb.Delete(r.Context())
+}
+
+func (h *Handler) top(r *http.Request, lb *rank.Leaderboard) (interface{}, error) {
+	n, err := intParam(r, "n", 10)
+	if err != nil {
+		return nil, err
+	}
+	if n < 1 {
+		return nil, invalidRange("n %d: must be at least 1", n)
+	}
+	members, err := lb.Top(r.Context(), n)
+	if err != nil {
+		return nil, err
+	}
+	return pageOf(members), nil
+}
+
+func (h *Handler) rankRange(r *http.Request, lb *rank.Leaderboard) (interface{}, error) {
+	from, err := intParam(r, "from", 1)
+	if err != nil {
+		return nil, err
+	}
+	to, err := intParam(r, "to", from+rank.DEFAULT_PAGESIZE-1)
+	if err != nil {
+		return nil, err
+	}
+	if from < 1 || to < from {
+		return nil, invalidRange("ranks %d..%d: from must be at least 1 and to at least from", from, to)
+	}
+	members, err := lb.MembersFromRankRange(r.Context(), from, to)
+	if err != nil {
+		return nil, err
+	}
+	return pageOf(members), nil
+}
+
+func (h *Handler) scoreRange(r *http.Request, lb *rank.Leaderboard) (interface{}, error) {
+	min, err := floatParam(r, "min")
+	if err != nil {
+		return nil, err
+	}
+	max, err := floatParam(r, "max")
+	if err != nil {
+		return nil, err
+	}
+	members, err := lb.MembersFromScoreRange(r.Context(), min, max)
+	if err != nil {
+		return nil, err
+	}
+	return pageOf(members), nil
+}
+
+func (h *Handler) rankMembers(r *http.Request, lb *rank.Leaderboard) (interface{}, error) {
+	var req RankMembersRequest
+	if err := decodeBody(r, &req); err != nil {
+		return nil, err
+	}
+	membersAndScores := make([]*rank.RankScore, 0, len(req.Members))
+	for _, m := range req.Members {
+		if m.Member == "" {
+			return nil, invalidRequest("members: empty member")
+		}
+		membersAndScores = append(membersAndScores, rank.NewRankScore(m.Member, m.Score))
+	}
+	if len(membersAndScores) == 0 {
+		return nil, nil
+	}
+	return nil, lb.RankMembers(r.Context(), membersAndScores)
+}
+
+func (h *Handler) member(r *http.Request, lb *rank.Leaderboard) (interface{}, error) {
+	m, err := lb.ScoreAndRankFor(r.Context(), r.PathValue("member"))
+	if err != nil {
+		return nil, err
+	}
+	return standingOf(m), nil
+}
+
+func (h *Handler) rankMember(r *http.Request, lb *rank.Leaderboard) (interface{}, error) {
+	var req RankRequest
+	if err := decodeBody(r, &req); err != nil {
+		return nil, err
+	}
+	member := r.PathValue("member")
+
+	if req.Policy == "" {
+		if err := lb.RankMember(r.Context(), member, req.Score); err != nil {
+			return nil, err
+		}
+		return h.member(r, lb)
+	}
+
+	switch req.Policy {
+	case rank.PolicyReplace, rank.PolicyIncrement, rank.PolicyMax, rank.PolicyMin:
+	default:
+		return nil, invalidRequest("policy: unknown %q", req.Policy)
+	}
+	res, err := lb.RankMemberWithPolicy(r.Context(), member, req.Score, req.Policy)
+	if err != nil {
+		return nil, err
+	}
+	return standingOf(&res.RankScore), nil
+}
+
+func (h *Handler) removeMember(r *http.Request, lb *rank.Leaderboard) (interface{}, error) {
+	member := r.PathValue("member")
+	if ok, err := lb.CheckMember(r.Context(), member); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, rank.ErrMemberNotFound
+	}
+	return nil, lb.RemoveMember(r.Context(), member)
+}
+
+func (h *Handler) changeScore(r *http.Request, lb *rank.Leaderboard) (interface{}, error) {
+	var req ChangeScoreRequest
+	if err := decodeBody(r, &req); err != nil {
+		return nil, err
+	}
+	if err := lb.ChangeScoreFor(r.Context(), r.PathValue("member"), req.Delta); err != nil {
+		return nil, err
+	}
+	return h.member(r, lb)
+}
+
+func (h *Handler) aroundMe(r *http.Request, lb *rank.Leaderboard) (interface{}, error) {
+	pageSize, err := intParam(r, "page_size", rank.DEFAULT_PAGESIZE)
+	if err != nil {
+		return nil, err
+	}
+	if pageSize < 1 {
+		return nil, invalidRange("page_size %d: must be at least 1", pageSize)
+	}
+	members, err := lb.AroundMe(r.Context(), r.PathValue("member"), pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return pageOf(members), nil
+}
+
+func (h *Handler) percentile(r *http.Request, lb *rank.Leaderboard) (interface{}, error) {
+	percentile, err := lb.PercentileFor(r.Context(), r.PathValue("member"))
+	if err != nil {
+		return nil, err
+	}
+	return map[string]int{"percentile": percentile}, nil
+}
@@ -0,0 +1,159 @@
+package rankhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	rank "github.com/jaksal/leaderboard"
+)
+
+const lbName = "test_http"
+
+func do(t *testing.T, h http.Handler, method string, path string, body string, res interface{}) int {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if res != nil && rec.Body.Len() > 0 {
+		if err := json.Unmarshal(rec.Body.Bytes(), res); err != nil {
+			t.Fatal("Handler Decode Err!", method, path, rec.Body.String())
+		}
+	}
+	return rec.Code
+}
+
+func TestHandler(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	h := NewHandler(rank.NewMemoryBackend())
+	base := "/leaderboards/" + lbName
+
+	var standing Standing
+	if code := do(t, h, "PUT", base+"/members/member_1", `{"score":10}`, &standing); code != http.StatusOK || standing.Rank != 1 || standing.Score != 10 {
+		t.Error("Handler RankMember Err!", code, standing)
+	}
+	if code := do(t, h, "POST", base+"/members", `{"members":[{"member":"member_2","score":20},{"member":"member_3","score":30}]}`, nil); code != http.StatusNoContent {
+		t.Error("Handler RankMembers Err!", code)
+	}
+	if code := do(t, h, "POST", base+"/members/member_1/score", `{"delta":15}`, &standing); code != http.StatusOK || standing.Score != 25 || standing.Rank != 2 {
+		t.Error("Handler ChangeScore Err!", code, standing)
+	}
+	if code := do(t, h, "PUT", base+"/members/member_1", `{"score":5,"policy":"max"}`, &standing); code != http.StatusOK || standing.Score != 25 {
+		t.Error("Handler RankMember policy Err!", code, standing)
+	}
+
+	var page Page
+	if code := do(t, h, "GET", base+"?page=1&page_size=2", "", &page); code != http.StatusOK || page.Total != 3 || len(page.Members) != 2 || page.Members[0].Member != "member_3" {
+		t.Error("Handler Members Err!", code, page)
+	}
+	page = Page{}
+	if code := do(t, h, "GET", base+"?cursor=&page_size=2", "", &page); code != http.StatusOK || len(page.Members) != 2 || page.Next == "" || page.Prev != "" {
+		t.Error("Handler Members Cursor Err!", code, page)
+	}
+	next := page.Next
+	page = Page{}
+	if code := do(t, h, "GET", base+"?page_size=2&cursor="+next, "", &page); code != http.StatusOK || len(page.Members) != 1 ||
+		page.Members[0].Member != "member_2" || page.Members[0].Rank != 3 || page.Next != "" || page.Prev == "" {
+		t.Error("Handler Members Cursor Err!", code, page)
+	}
+	page = Page{}
+	if code := do(t, h, "GET", base+"/top?n=1", "", &page); code != http.StatusOK || len(page.Members) != 1 || page.Members[0].Member != "member_3" {
+		t.Error("Handler Top Err!", code, page)
+	}
+	page = Page{}
+	if code := do(t, h, "GET", base+"/scores?min=20&max=25", "", &page); code != http.StatusOK || len(page.Members) != 2 {
+		t.Error("Handler Scores Err!", code, page)
+	}
+	page = Page{}
+	if code := do(t, h, "GET", base+"/ranks?from=2&to=3", "", &page); code != http.StatusOK || len(page.Members) != 2 || page.Members[0].Rank != 2 {
+		t.Error("Handler Ranks Err!", code, page)
+	}
+	page = Page{}
+	if code := do(t, h, "GET", base+"/members/member_1/around?page_size=3", "", &page); code != http.StatusOK || len(page.Members) != 3 {
+		t.Error("Handler AroundMe Err!", code, page)
+	}
+	var percentile map[string]int
+	if code := do(t, h, "GET", base+"/members/member_3/percentile", "", &percentile); code != http.StatusOK || percentile["percentile"] != 67 {
+		t.Error("Handler Percentile Err!", code, percentile)
+	}
+
+	// errors.
+	var res map[string]*Error
+	for _, c := range []struct {
+		method string
+		path   string
+		body   string
+		status int
+		code   string
+	}{
+		{"GET", base + "/members/unknown", "", http.StatusNotFound, CodeMemberNotFound},
+		{"GET", base + "/members/unknown/around", "", http.StatusNotFound, CodeMemberNotFound},
+		{"GET", base + "/members/unknown/percentile", "", http.StatusNotFound, CodeMemberNotFound},
+		{"DELETE", base + "/members/unknown", "", http.StatusNotFound, CodeMemberNotFound},
+		{"GET", base + "/ranks?from=3&to=1", "", http.StatusBadRequest, CodeInvalidRange},
+		{"GET", base + "/scores?min=30&max=10", "", http.StatusBadRequest, CodeInvalidRange},
+		{"GET", base + "?page=0", "", http.StatusBadRequest, CodeInvalidRange},
+		{"GET", base + "?cursor=x", "", http.StatusBadRequest, CodeInvalidRequest},
+		{"GET", base + "/top?n=x", "", http.StatusBadRequest, CodeInvalidRequest},
+		{"PUT", base + "/members/member_1", `{"score":`, http.StatusBadRequest, CodeInvalidRequest},
+		{"PUT", base + "/members/member_1", `{"score":1,"policy":"best"}`, http.StatusBadRequest, CodeInvalidRequest},
+	} {
+		res = nil
+		if code := do(t, h, c.method, c.path, c.body, &res); code != c.status || res["error"] == nil || res["error"].Code != c.code {
+			t.Error("Handler Error Err!", c.method, c.path, code, res)
+		}
+	}
+
+	if code := do(t, h, "DELETE", base+"/members/member_1", "", nil); code != http.StatusNoContent {
+		t.Error("Handler RemoveMember Err!", code)
+	}
+	if code := do(t, h, "DELETE", base, "", nil); code != http.StatusNoContent {
+		t.Error("Handler Delete Err!", code)
+	}
+	page = Page{}
+	if code := do(t, h, "GET", base, "", &page); code != http.StatusOK || page.Total != 0 || len(page.Members) != 0 {
+		t.Error("Handler Members after Delete Err!", code, page)
+	}
+}
+
+func TestHandlerNames(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	h := NewHandler(rank.NewMemoryBackend())
+	for _, name := range []string{lbName + ":data", lbName + ":ts", lbName + ":top:10", lbName + ":season:2024-s1", lbName + ":seasons"} {
+		var res map[string]*Error
+		if code := do(t, h, "GET", "/leaderboards/"+name, "", &res); code != http.StatusBadRequest || res["error"].Code != CodeInvalidRequest {
+			t.Error("Handler ValidName Err!", name, code, res)
+		}
+	}
+	if code := do(t, h, "GET", "/leaderboards/"+lbName+":topscores", "", nil); code != http.StatusOK {
+		t.Error("Handler ValidName Err!", code)
+	}
+
+	// an allow-list.
+	h.WithNameValidator(func(name string) error {
+		if name != lbName {
+			return errors.New("unknown leaderboard")
+		}
+		return nil
+	})
+	if code := do(t, h, "GET", "/leaderboards/other", "", nil); code != http.StatusBadRequest {
+		t.Error("Handler WithNameValidator Err!", code)
+	}
+	if code := do(t, h, "GET", "/leaderboards/"+lbName, "", nil); code != http.StatusOK {
+		t.Error("Handler WithNameValidator Err!", code)
+	}
+
+	body := `{"members":[{"member":"` + strings.Repeat("m", MaxBodySize) + `","score":1}]}`
+	var res map[string]*Error
+	if code := do(t, h, "POST", "/leaderboards/"+lbName+"/members", body, &res); code != http.StatusRequestEntityTooLarge || res["error"].Code != CodeBodyTooLarge {
+		t.Error("Handler MaxBodySize Err!", code, res)
+	}
+	for _, bound := range []string{"NaN", "Inf", "-Inf"} {
+		if code := do(t, h, "GET", "/leaderboards/"+lbName+"/scores?min="+bound+"&max=10", "", nil); code != http.StatusBadRequest {
+			t.Error("Handler floatParam Err!", bound, code)
+		}
+	}
+}
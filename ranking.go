@@ -0,0 +1,419 @@
+package rank
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// RankingMode : how members with the same score are ranked.
+type RankingMode int
+
+const (
+	// RankStandard : standard competition ranking. ties share the rank and leave a gap (1,1,3).
+	RankStandard RankingMode = iota
+	// RankDense : ties share the rank without leaving a gap (1,1,2).
+	RankDense
+	// RankOrdinal : every member gets its own rank (1,2,3). ties are ordered by member name like ZREVRANGE.
+	RankOrdinal
+	// RankEarliestFirst : every member gets its own rank. among ties the earlier submission wins.
+	// resubmitting the same score keeps the time of the first submission.
+	RankEarliestFirst
+)
+
+// rankingModeNames : names of the ranking modes, e.g. in command line flags.
+var rankingModeNames = map[RankingMode]string{
+	RankStandard:      "standard",
+	RankDense:         "dense",
+	RankOrdinal:       "ordinal",
+	RankEarliestFirst: "earliest",
+}
+
+func (mode RankingMode) String() string {
+	if name, ok := rankingModeNames[mode]; ok {
+		return name
+	}
+	return "RankingMode(" + strconv.Itoa(int(mode)) + ")"
+}
+
+// ParseRankingMode : ranking mode of its name, "standard", "dense", "ordinal" or "earliest".
+func ParseRankingMode(name string) (RankingMode, error) {
+	for mode, s := range rankingModeNames {
+		if s == name {
+			return mode, nil
+		}
+	}
+	return RankStandard, fmt.Errorf("rank: unknown ranking mode %q", name)
+}
+
+// WithRankingMode : rank the members of the leaderboard with mode. default RankStandard.
+func WithRankingMode(mode RankingMode) Option {
+	return func(lb *Leaderboard) {
+		lb.rankingMode = mode
+	}
+}
+
+// timestampKey : hash of member -> time the member got its score, kept for RankEarliestFirst.
+func (lb *Leaderboard) timestampKey() string {
+	return lb.Name + ":ts"
+}
+
+// submit : store entries applying policy, PolicyReplace or PolicyIncrement, and record the submission time of
+// the members whose score changed. a resubmitted score keeps its time. only RankEarliestFirst needs to know
+// which scores changed, the other modes write with ZAdd and ZIncrBy.
+func (lb *Leaderboard) submit(ctx context.Context, policy UpdatePolicy, entries ...Entry) error {
+	if lb.rankingMode != RankEarliestFirst {
+		if policy != PolicyIncrement {
+			return lb.backend.ZAdd(ctx, lb.Name, entries...)
+		}
+		for _, entry := range entries {
+			if _, err := lb.backend.ZIncrBy(ctx, lb.Name, entry.Member, entry.Score); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	updates, err := lb.backend.ZUpdate(ctx, lb.Name, policy, entries...)
+	if err != nil {
+		return err
+	}
+	var changed []string
+	for _, update := range updates {
+		if update.Changed {
+			changed = append(changed, update.Member)
+		}
+	}
+	return lb.touch(ctx, changed...)
+}
+
+// touch : record the submission time of members whose score changed.
+func (lb *Leaderboard) touch(ctx context.Context, members ...string) error {
+	if lb.rankingMode != RankEarliestFirst || len(members) == 0 {
+		return nil
+	}
+
+	now := strconv.FormatInt(lb.now().UnixNano(), 10)
+	values := make(map[string]string, len(members))
+	for _, member := range members {
+		values[member] = now
+	}
+	return lb.backend.HSet(ctx, lb.timestampKey(), values)
+}
+
+// rankFor : rank of member holding score under the ranking mode of the leaderboard.
+func (lb *Leaderboard) rankFor(ctx context.Context, member string, score float64) (int, error) {
+	switch lb.rankingMode {
+	case RankDense:
+		min, max := lb.betterRange(score)
+		better, err := lb.backend.ZCountDistinct(ctx, lb.Name, min, max)
+		if err != nil {
+			return -1, err
+		}
+		return better + 1, nil
+
+	case RankOrdinal:
+		position, found, err := lb.indexOf(ctx, member)
+		if err != nil {
+			return -1, err
+		}
+		if !found {
+			return -1, ErrMemberNotFound
+		}
+		return position + 1, nil
+
+	case RankEarliestFirst:
+		min, max := lb.betterRange(score)
+		better, err := lb.backend.ZCount(ctx, lb.Name, min, max)
+		if err != nil {
+			return -1, err
+		}
+		ties, err := lb.byScore(ctx, scoreBound(score, false), scoreBound(score, false))
+		if err != nil {
+			return -1, err
+		}
+		if ties, err = lb.sortTies(ctx, ties); err != nil {
+			return -1, err
+		}
+		for i, entry := range ties {
+			if entry.Member == member {
+				return better + i + 1, nil
+			}
+		}
+		return -1, ErrMemberNotFound
+
+	default:
+		min, max := lb.betterRange(score)
+		better, err := lb.backend.ZCount(ctx, lb.Name, min, max)
+		if err != nil {
+			return -1, err
+		}
+		return better + 1, nil
+	}
+}
+
+// ranksFor : rankFor of every member in a fixed number of round trips.
+// scores and found come from ZMScore. members not found get rank -1.
+func (lb *Leaderboard) ranksFor(ctx context.Context, members []string, scores []float64, found []bool) ([]int, error) {
+	ranks := make([]int, len(members))
+	var present []int
+	for i := range members {
+		ranks[i] = -1
+		if found[i] {
+			present = append(present, i)
+		}
+	}
+	if len(present) == 0 {
+		return ranks, nil
+	}
+
+	switch lb.rankingMode {
+	case RankOrdinal:
+		names := make([]string, 0, len(present))
+		for _, i := range present {
+			names = append(names, members[i])
+		}
+		positions, ok, err := lb.indexesOf(ctx, names...)
+		if err != nil {
+			return nil, err
+		}
+		for j, i := range present {
+			if ok[j] {
+				ranks[i] = positions[j] + 1
+			}
+		}
+
+	case RankEarliestFirst:
+		// the better members and the ties of every distinct score, then one lookup of the times of the ties.
+		var distinct []float64
+		seen := make(map[float64]bool)
+		for _, i := range present {
+			if !seen[scores[i]] {
+				seen[scores[i]] = true
+				distinct = append(distinct, scores[i])
+			}
+		}
+		betterRanges := make([]ScoreRange, 0, len(distinct))
+		tieRanges := make([]ScoreRange, 0, len(distinct))
+		for _, score := range distinct {
+			min, max := lb.betterRange(score)
+			betterRanges = append(betterRanges, ScoreRange{Min: min, Max: max})
+			tieRanges = append(tieRanges, ScoreRange{Min: scoreBound(score, false), Max: scoreBound(score, false)})
+		}
+		better, err := lb.backend.ZMCount(ctx, lb.Name, betterRanges...)
+		if err != nil {
+			return nil, err
+		}
+		ties, err := lb.backend.ZMRangeByScore(ctx, lb.Name, tieRanges...)
+		if err != nil {
+			return nil, err
+		}
+
+		var tied []string
+		for j := range ties {
+			if lb.order != Ascending {
+				// the member order of byScore.
+				for a, b := 0, len(ties[j])-1; a < b; a, b = a+1, b-1 {
+					ties[j][a], ties[j][b] = ties[j][b], ties[j][a]
+				}
+			}
+			if len(ties[j]) > 1 {
+				for _, entry := range ties[j] {
+					tied = append(tied, entry.Member)
+				}
+			}
+		}
+		timestamps := make(map[string]int64)
+		if len(tied) > 0 {
+			if timestamps, err = lb.timestamps(ctx, tied...); err != nil {
+				return nil, err
+			}
+		}
+
+		positions := make(map[string]int)
+		for j := range ties {
+			lb.sortByTime(ties[j], timestamps)
+			for k, entry := range ties[j] {
+				positions[entry.Member] = better[j] + k
+			}
+		}
+		for _, i := range present {
+			if position, ok := positions[members[i]]; ok {
+				ranks[i] = position + 1
+			}
+		}
+
+	default:
+		ranges := make([]ScoreRange, 0, len(present))
+		for _, i := range present {
+			min, max := lb.betterRange(scores[i])
+			ranges = append(ranges, ScoreRange{Min: min, Max: max})
+		}
+		var better []int
+		var err error
+		if lb.rankingMode == RankDense {
+			better, err = lb.backend.ZMCountDistinct(ctx, lb.Name, ranges...)
+		} else {
+			better, err = lb.backend.ZMCount(ctx, lb.Name, ranges...)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for j, i := range present {
+			ranks[i] = better[j] + 1
+		}
+	}
+	return ranks, nil
+}
+
+// positionFor : zero based position of member in the leaderboard order.
+func (lb *Leaderboard) positionFor(ctx context.Context, member string) (int, error) {
+	if lb.rankingMode == RankEarliestFirst {
+		score, err := lb.scoreFor(ctx, member)
+		if err != nil {
+			return -1, err
+		}
+		rank, err := lb.rankFor(ctx, member, score)
+		return rank - 1, err
+	}
+
+	position, found, err := lb.indexOf(ctx, member)
+	if err != nil {
+		return -1, err
+	}
+	if !found {
+		return -1, ErrMemberNotFound
+	}
+	return position, nil
+}
+
+// positionOf : zero based position of entry in the leaderboard order, from its score, its submission time for
+// RankEarliestFirst and its member. unlike positionFor the member may have left since entry was read. it then gives
+// the position of the member that took its place.
+func (lb *Leaderboard) positionOf(ctx context.Context, entry Entry, time int64) (int, error) {
+	if lb.rankingMode != RankEarliestFirst {
+		return lb.backend.ZRankOf(ctx, lb.Name, entry.Score, entry.Member, lb.order != Ascending)
+	}
+
+	min, max := lb.betterRange(entry.Score)
+	better, err := lb.backend.ZCount(ctx, lb.Name, min, max)
+	if err != nil {
+		return -1, err
+	}
+	ties, err := lb.byScore(ctx, scoreBound(entry.Score, false), scoreBound(entry.Score, false))
+	if err != nil {
+		return -1, err
+	}
+	members := make([]string, 0, len(ties))
+	for _, tie := range ties {
+		members = append(members, tie.Member)
+	}
+	timestamps, err := lb.timestamps(ctx, members...)
+	if err != nil {
+		return -1, err
+	}
+
+	// the ties before entry are the ones after a backward cursor at it.
+	c := &cursor{backward: true, score: entry.Score, member: entry.Member, time: time}
+	position := better
+	for _, member := range members {
+		if lb.follows(c, member, timestamps[member]) {
+			position++
+		}
+	}
+	return position, nil
+}
+
+// timestamps : last submission time of members, for RankEarliestFirst.
+// members without a timestamp were ranked before the mode was set. they get math.MaxInt64 and go last.
+func (lb *Leaderboard) timestamps(ctx context.Context, members ...string) (map[string]int64, error) {
+	values, err := lb.backend.HMGet(ctx, lb.timestampKey(), members...)
+	if err != nil {
+		return nil, err
+	}
+
+	timestamps := make(map[string]int64, len(members))
+	for _, member := range members {
+		timestamps[member] = math.MaxInt64
+		if value, ok := values[member]; ok {
+			timestamps[member], _ = strconv.ParseInt(value, 10, 64)
+		}
+	}
+	return timestamps, nil
+}
+
+// sortTies : order entries sorted by score so that ties follow the ranking mode.
+func (lb *Leaderboard) sortTies(ctx context.Context, entries []Entry) ([]Entry, error) {
+	if lb.rankingMode != RankEarliestFirst || len(entries) < 2 {
+		return entries, nil
+	}
+
+	members := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		members = append(members, entry.Member)
+	}
+	timestamps, err := lb.timestamps(ctx, members...)
+	if err != nil {
+		return nil, err
+	}
+
+	lb.sortByTime(entries, timestamps)
+	return entries, nil
+}
+
+// sortByTime : sort entries sorted by score so that the earlier submission comes first among ties.
+func (lb *Leaderboard) sortByTime(entries []Entry, timestamps map[string]int64) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Score != entries[j].Score {
+			return lb.better(entries[i].Score, entries[j].Score)
+		}
+		return timestamps[entries[i].Member] < timestamps[entries[j].Member]
+	})
+}
+
+// rangeByPosition : entries at the zero based positions start..stop in the leaderboard order.
+func (lb *Leaderboard) rangeByPosition(ctx context.Context, start int, stop int) ([]Entry, error) {
+	entries, err := lb.byIndex(ctx, start, stop)
+	if err != nil || lb.rankingMode != RankEarliestFirst || len(entries) == 0 {
+		return entries, err
+	}
+
+	// ties crossing the edges of the range are fetched whole, ordered by time and cut again.
+	if start < 0 {
+		count, err := lb.backend.ZCard(ctx, lb.Name)
+		if err != nil {
+			return nil, err
+		}
+		if start += count; start < 0 {
+			start = 0
+		}
+	}
+
+	min, max := lb.betterRange(entries[0].Score)
+	offset, err := lb.backend.ZCount(ctx, lb.Name, min, max)
+	if err != nil {
+		return nil, err
+	}
+
+	low, high := entries[0].Score, entries[len(entries)-1].Score
+	if low > high {
+		low, high = high, low
+	}
+	all, err := lb.byScore(ctx, scoreBound(low, false), scoreBound(high, false))
+	if err != nil {
+		return nil, err
+	}
+	if all, err = lb.sortTies(ctx, all); err != nil {
+		return nil, err
+	}
+
+	from := start - offset
+	to := from + len(entries)
+	if from < 0 || to > len(all) {
+		// the leaderboard changed between the calls.
+		return entries, nil
+	}
+	return all[from:to], nil
+}
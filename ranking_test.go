@@ -0,0 +1,166 @@
+package rank
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func rankingModeLeaderboard(b Backend, mode RankingMode) *Leaderboard {
+	ctx := context.Background()
+	lb := NewLeaderboardWithBackend(b, lbName, WithRankingMode(mode))
+	lb.Delete(ctx)
+
+	// member_a is submitted before member_b with the same score.
+	lb.RankMember(ctx, "member_a", 50)
+	time.Sleep(time.Millisecond)
+	lb.RankMember(ctx, "member_b", 50)
+	lb.RankMember(ctx, "member_c", 30)
+	lb.RankMember(ctx, "member_d", 10)
+	return lb
+}
+
+func testRankingMode(t *testing.T, b Backend, mode RankingMode, expected map[string]int) {
+	ctx := context.Background()
+	lb := rankingModeLeaderboard(b, mode)
+	defer lb.Delete(ctx)
+
+	for member, rank := range expected {
+		if r, _ := lb.RankFor(ctx, member); r != rank {
+			t.Error("Leaderboard RankFor Err!", mode, member, r, rank)
+		}
+	}
+
+	members, _ := lb.Members(ctx, 1, 0)
+	if len(members) != len(expected) {
+		t.Fatal("Leaderboard Members Err!", mode, members)
+	}
+	for _, member := range members {
+		if member.rank != expected[member.Member] {
+			t.Error("Leaderboard Members Err!", mode, member)
+		}
+	}
+	ranked, _ := lb.RankedInList(ctx, []string{"member_b", "member_d"})
+	for _, member := range ranked {
+		if member.rank != expected[member.Member] {
+			t.Error("Leaderboard RankedInList Err!", mode, member)
+		}
+	}
+}
+
+func TestRankingMode(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	for _, b := range []Backend{backend, NewMemoryBackend()} {
+		testRankingMode(t, b, RankStandard, map[string]int{"member_a": 1, "member_b": 1, "member_c": 3, "member_d": 4})
+		testRankingMode(t, b, RankDense, map[string]int{"member_a": 1, "member_b": 1, "member_c": 2, "member_d": 3})
+		testRankingMode(t, b, RankOrdinal, map[string]int{"member_b": 1, "member_a": 2, "member_c": 3, "member_d": 4})
+		testRankingMode(t, b, RankEarliestFirst, map[string]int{"member_a": 1, "member_b": 2, "member_c": 3, "member_d": 4})
+	}
+}
+
+func TestRankEarliestFirst(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	ctx := context.Background()
+	lb := rankingModeLeaderboard(backend, RankEarliestFirst)
+	defer lb.Delete(ctx)
+
+	// the tie between member_a and member_b is split across pages.
+	if members, _ := lb.Members(ctx, 1, 1); len(members) != 1 || members[0].Member != "member_a" {
+		t.Error("Leaderboard Members Err!", members)
+	}
+	if members, _ := lb.Members(ctx, 2, 1); len(members) != 1 || members[0].Member != "member_b" {
+		t.Error("Leaderboard Members Err!", members)
+	}
+	if members, _ := lb.AroundMe(ctx, "member_b", 2); len(members) != 2 ||
+		members[0].Member != "member_a" || members[1].Member != "member_b" {
+		t.Error("Leaderboard AroundMe Err!", members)
+	}
+	if percentile, _ := lb.PercentileFor(ctx, "member_a"); percentile != 75 {
+		t.Error("Leaderboard PercentileFor Err!", percentile)
+	}
+
+	// getting back to the tie with a new score moves member_a behind member_b.
+	lb.ChangeScoreFor(ctx, "member_a", -1)
+	lb.ChangeScoreFor(ctx, "member_a", 1)
+	if rank, _ := lb.RankFor(ctx, "member_a"); rank != 2 {
+		t.Error("Leaderboard RankFor Err!", rank)
+	}
+
+	if count, _ := lb.RemoveMembersOutsideRank(ctx, 1); count != 3 {
+		t.Error("Leaderboard RemoveMembersOutsideRank Err!", count)
+	}
+	if members, _ := lb.AllMembers(ctx); len(members) != 1 || members[0].Member != "member_b" {
+		t.Error("Leaderboard AllMembers Err!", members)
+	}
+	// submission times come from the clock of the leaderboard.
+	now := time.UnixMilli(1700000000000)
+	lb.now = func() time.Time { return now }
+	lb.RankMember(ctx, "member_e", 10)
+	now = now.Add(-time.Hour)
+	lb.RankMember(ctx, "member_f", 10)
+	if members, _ := lb.RankedInList(ctx, []string{"member_e", "member_f"}); len(members) != 2 || members[0].rank != 3 || members[1].rank != 2 {
+		t.Error("Leaderboard RankedInList Clock Err!", members)
+	}
+}
+
+func testRankEarliestFirstResubmit(t *testing.T, b Backend) {
+	ctx := context.Background()
+	lb := rankingModeLeaderboard(b, RankEarliestFirst)
+	defer lb.Delete(ctx)
+
+	// resubmitting the same score keeps the first submission time of member_a.
+	for name, resubmit := range map[string]func() error{
+		"RankMember":     func() error { return lb.RankMember(ctx, "member_a", 50) },
+		"RankMembers":    func() error { return lb.RankMembers(ctx, []*RankScore{NewRankScore("member_a", 50)}) },
+		"ChangeScoreFor": func() error { return lb.ChangeScoreFor(ctx, "member_a", 0) },
+		"RankMembersBatch": func() error {
+			_, err := lb.RankMembersBatch(ctx, []*RankScore{NewRankScore("member_a", 50)}, BatchOptions{})
+			return err
+		},
+		"RankMemberWithPolicy": func() error {
+			_, err := lb.RankMemberWithPolicy(ctx, "member_a", 50, PolicyReplace)
+			return err
+		},
+	} {
+		time.Sleep(time.Millisecond)
+		if err := resubmit(); err != nil {
+			t.Error("Leaderboard "+name+" Resubmit Err!", err)
+		}
+		if rank, _ := lb.RankFor(ctx, "member_a"); rank != 1 {
+			t.Error("Leaderboard "+name+" Resubmit Err!", rank)
+		}
+	}
+}
+
+func TestRankEarliestFirstResubmit(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	testRankEarliestFirstResubmit(t, backend)
+	testRankEarliestFirstResubmit(t, NewMemoryBackend())
+}
+
+func TestPercentileForStandard(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	ctx := context.Background()
+	lb := rankingModeLeaderboard(backend, RankStandard)
+	defer lb.Delete(ctx)
+
+	// tied members share the percentile like they share the rank.
+	if percentile, _ := lb.PercentileFor(ctx, "member_a"); percentile != 50 {
+		t.Error("Leaderboard PercentileFor Err!", percentile)
+	}
+	if percentile, _ := lb.PercentileFor(ctx, "member_b"); percentile != 50 {
+		t.Error("Leaderboard PercentileFor Err!", percentile)
+	}
+}
+
+func TestParseRankingMode(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	for _, mode := range []RankingMode{RankStandard, RankDense, RankOrdinal, RankEarliestFirst} {
+		if parsed, err := ParseRankingMode(mode.String()); err != nil || parsed != mode {
+			t.Error("ParseRankingMode Err!", mode, parsed, err)
+		}
+	}
+	if _, err := ParseRankingMode("fastest"); err == nil {
+		t.Error("ParseRankingMode Err!", err)
+	}
+}
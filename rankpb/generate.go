@@ -0,0 +1,4 @@
+// Package rankpb : protobuf messages and grpc client of the leaderboard service. see leaderboard.proto.
+package rankpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative leaderboard.proto
@@ -0,0 +1,1113 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v5.29.3
+// source: leaderboard.proto
+
+// leaderboard operations of package rank over grpc. see package rankgrpc for the server.
+
+package rankpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// UpdatePolicy : rank.UpdatePolicy.
+type UpdatePolicy int32
+
+const (
+	UpdatePolicy_UPDATE_POLICY_UNSPECIFIED UpdatePolicy = 0
+	UpdatePolicy_UPDATE_POLICY_REPLACE     UpdatePolicy = 1
+	UpdatePolicy_UPDATE_POLICY_INCREMENT   UpdatePolicy = 2
+	UpdatePolicy_UPDATE_POLICY_MAX         UpdatePolicy = 3
+	UpdatePolicy_UPDATE_POLICY_MIN         UpdatePolicy = 4
+)
+
+// Enum value maps for UpdatePolicy.
+var (
+	UpdatePolicy_name = map[int32]string{
+		0: "UPDATE_POLICY_UNSPECIFIED",
+		1: "UPDATE_POLICY_REPLACE",
+		2: "UPDATE_POLICY_INCREMENT",
+		3: "UPDATE_POLICY_MAX",
+		4: "UPDATE_POLICY_MIN",
+	}
+	UpdatePolicy_value = map[string]int32{
+		"UPDATE_POLICY_UNSPECIFIED": 0,
+		"UPDATE_POLICY_REPLACE":     1,
+		"UPDATE_POLICY_INCREMENT":   2,
+		"UPDATE_POLICY_MAX":         3,
+		"UPDATE_POLICY_MIN":         4,
+	}
+)
+
+func (x UpdatePolicy) Enum() *UpdatePolicy {
+	p := new(UpdatePolicy)
+	*p = x
+	return p
+}
+
+func (x UpdatePolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UpdatePolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_leaderboard_proto_enumTypes[0].Descriptor()
+}
+
+func (UpdatePolicy) Type() protoreflect.EnumType {
+	return &file_leaderboard_proto_enumTypes[0]
+}
+
+func (x UpdatePolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UpdatePolicy.Descriptor instead.
+func (UpdatePolicy) EnumDescriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{0}
+}
+
+type Standing struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        string                 `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	Rank          int32                  `protobuf:"varint,3,opt,name=rank,proto3" json:"rank,omitempty"`
+	Data          string                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Standing) Reset() {
+	*x = Standing{}
+	mi := &file_leaderboard_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Standing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Standing) ProtoMessage() {}
+
+func (x *Standing) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Standing.ProtoReflect.Descriptor instead.
+func (*Standing) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Standing) GetMember() string {
+	if x != nil {
+		return x.Member
+	}
+	return ""
+}
+
+func (x *Standing) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Standing) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *Standing) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+type Page struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Members []*Standing            `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	// total, page, page_size : set by Members.
+	Total         int32 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32 `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Page) Reset() {
+	*x = Page{}
+	mi := &file_leaderboard_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Page) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Page) ProtoMessage() {}
+
+func (x *Page) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Page.ProtoReflect.Descriptor instead.
+func (*Page) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Page) GetMembers() []*Standing {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *Page) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *Page) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *Page) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type LeaderboardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Leaderboard   string                 `protobuf:"bytes,1,opt,name=leaderboard,proto3" json:"leaderboard,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderboardRequest) Reset() {
+	*x = LeaderboardRequest{}
+	mi := &file_leaderboard_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardRequest) ProtoMessage() {}
+
+func (x *LeaderboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardRequest.ProtoReflect.Descriptor instead.
+func (*LeaderboardRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LeaderboardRequest) GetLeaderboard() string {
+	if x != nil {
+		return x.Leaderboard
+	}
+	return ""
+}
+
+type MemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Leaderboard   string                 `protobuf:"bytes,1,opt,name=leaderboard,proto3" json:"leaderboard,omitempty"`
+	Member        string                 `protobuf:"bytes,2,opt,name=member,proto3" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemberRequest) Reset() {
+	*x = MemberRequest{}
+	mi := &file_leaderboard_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemberRequest) ProtoMessage() {}
+
+func (x *MemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemberRequest.ProtoReflect.Descriptor instead.
+func (*MemberRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MemberRequest) GetLeaderboard() string {
+	if x != nil {
+		return x.Leaderboard
+	}
+	return ""
+}
+
+func (x *MemberRequest) GetMember() string {
+	if x != nil {
+		return x.Member
+	}
+	return ""
+}
+
+type RankMemberRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Leaderboard string                 `protobuf:"bytes,1,opt,name=leaderboard,proto3" json:"leaderboard,omitempty"`
+	Member      string                 `protobuf:"bytes,2,opt,name=member,proto3" json:"member,omitempty"`
+	Score       float64                `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	// policy : UNSPECIFIED always stores the score.
+	Policy        UpdatePolicy `protobuf:"varint,4,opt,name=policy,proto3,enum=rank.v1.UpdatePolicy" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RankMemberRequest) Reset() {
+	*x = RankMemberRequest{}
+	mi := &file_leaderboard_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankMemberRequest) ProtoMessage() {}
+
+func (x *RankMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankMemberRequest.ProtoReflect.Descriptor instead.
+func (*RankMemberRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RankMemberRequest) GetLeaderboard() string {
+	if x != nil {
+		return x.Leaderboard
+	}
+	return ""
+}
+
+func (x *RankMemberRequest) GetMember() string {
+	if x != nil {
+		return x.Member
+	}
+	return ""
+}
+
+func (x *RankMemberRequest) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *RankMemberRequest) GetPolicy() UpdatePolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return UpdatePolicy_UPDATE_POLICY_UNSPECIFIED
+}
+
+type RankMembersRequest struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Leaderboard   string                      `protobuf:"bytes,1,opt,name=leaderboard,proto3" json:"leaderboard,omitempty"`
+	Members       []*RankMembersRequest_Entry `protobuf:"bytes,2,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RankMembersRequest) Reset() {
+	*x = RankMembersRequest{}
+	mi := &file_leaderboard_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankMembersRequest) ProtoMessage() {}
+
+func (x *RankMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankMembersRequest.ProtoReflect.Descriptor instead.
+func (*RankMembersRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RankMembersRequest) GetLeaderboard() string {
+	if x != nil {
+		return x.Leaderboard
+	}
+	return ""
+}
+
+func (x *RankMembersRequest) GetMembers() []*RankMembersRequest_Entry {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type ChangeScoreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Leaderboard   string                 `protobuf:"bytes,1,opt,name=leaderboard,proto3" json:"leaderboard,omitempty"`
+	Member        string                 `protobuf:"bytes,2,opt,name=member,proto3" json:"member,omitempty"`
+	Delta         float64                `protobuf:"fixed64,3,opt,name=delta,proto3" json:"delta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeScoreRequest) Reset() {
+	*x = ChangeScoreRequest{}
+	mi := &file_leaderboard_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeScoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeScoreRequest) ProtoMessage() {}
+
+func (x *ChangeScoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeScoreRequest.ProtoReflect.Descriptor instead.
+func (*ChangeScoreRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ChangeScoreRequest) GetLeaderboard() string {
+	if x != nil {
+		return x.Leaderboard
+	}
+	return ""
+}
+
+func (x *ChangeScoreRequest) GetMember() string {
+	if x != nil {
+		return x.Member
+	}
+	return ""
+}
+
+func (x *ChangeScoreRequest) GetDelta() float64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+type PercentileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Percentile    int32                  `protobuf:"varint,1,opt,name=percentile,proto3" json:"percentile,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PercentileResponse) Reset() {
+	*x = PercentileResponse{}
+	mi := &file_leaderboard_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PercentileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PercentileResponse) ProtoMessage() {}
+
+func (x *PercentileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PercentileResponse.ProtoReflect.Descriptor instead.
+func (*PercentileResponse) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PercentileResponse) GetPercentile() int32 {
+	if x != nil {
+		return x.Percentile
+	}
+	return 0
+}
+
+type TotalMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         int32                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TotalMembersResponse) Reset() {
+	*x = TotalMembersResponse{}
+	mi := &file_leaderboard_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TotalMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TotalMembersResponse) ProtoMessage() {}
+
+func (x *TotalMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TotalMembersResponse.ProtoReflect.Descriptor instead.
+func (*TotalMembersResponse) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TotalMembersResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type MembersRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Leaderboard string                 `protobuf:"bytes,1,opt,name=leaderboard,proto3" json:"leaderboard,omitempty"`
+	// page : from 1.
+	Page int32 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	// page_size : 0 is 25.
+	PageSize      int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MembersRequest) Reset() {
+	*x = MembersRequest{}
+	mi := &file_leaderboard_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MembersRequest) ProtoMessage() {}
+
+func (x *MembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MembersRequest.ProtoReflect.Descriptor instead.
+func (*MembersRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MembersRequest) GetLeaderboard() string {
+	if x != nil {
+		return x.Leaderboard
+	}
+	return ""
+}
+
+func (x *MembersRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *MembersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type TopRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Leaderboard   string                 `protobuf:"bytes,1,opt,name=leaderboard,proto3" json:"leaderboard,omitempty"`
+	N             int32                  `protobuf:"varint,2,opt,name=n,proto3" json:"n,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopRequest) Reset() {
+	*x = TopRequest{}
+	mi := &file_leaderboard_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopRequest) ProtoMessage() {}
+
+func (x *TopRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopRequest.ProtoReflect.Descriptor instead.
+func (*TopRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TopRequest) GetLeaderboard() string {
+	if x != nil {
+		return x.Leaderboard
+	}
+	return ""
+}
+
+func (x *TopRequest) GetN() int32 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+type RankRangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Leaderboard   string                 `protobuf:"bytes,1,opt,name=leaderboard,proto3" json:"leaderboard,omitempty"`
+	From          int32                  `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            int32                  `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RankRangeRequest) Reset() {
+	*x = RankRangeRequest{}
+	mi := &file_leaderboard_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankRangeRequest) ProtoMessage() {}
+
+func (x *RankRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankRangeRequest.ProtoReflect.Descriptor instead.
+func (*RankRangeRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RankRangeRequest) GetLeaderboard() string {
+	if x != nil {
+		return x.Leaderboard
+	}
+	return ""
+}
+
+func (x *RankRangeRequest) GetFrom() int32 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *RankRangeRequest) GetTo() int32 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+type ScoreRangeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Leaderboard   string                 `protobuf:"bytes,1,opt,name=leaderboard,proto3" json:"leaderboard,omitempty"`
+	Min           float64                `protobuf:"fixed64,2,opt,name=min,proto3" json:"min,omitempty"`
+	Max           float64                `protobuf:"fixed64,3,opt,name=max,proto3" json:"max,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScoreRangeRequest) Reset() {
+	*x = ScoreRangeRequest{}
+	mi := &file_leaderboard_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScoreRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreRangeRequest) ProtoMessage() {}
+
+func (x *ScoreRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreRangeRequest.ProtoReflect.Descriptor instead.
+func (*ScoreRangeRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ScoreRangeRequest) GetLeaderboard() string {
+	if x != nil {
+		return x.Leaderboard
+	}
+	return ""
+}
+
+func (x *ScoreRangeRequest) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *ScoreRangeRequest) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+type AroundMeRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Leaderboard string                 `protobuf:"bytes,1,opt,name=leaderboard,proto3" json:"leaderboard,omitempty"`
+	Member      string                 `protobuf:"bytes,2,opt,name=member,proto3" json:"member,omitempty"`
+	// page_size : 0 is 25.
+	PageSize      int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AroundMeRequest) Reset() {
+	*x = AroundMeRequest{}
+	mi := &file_leaderboard_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AroundMeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AroundMeRequest) ProtoMessage() {}
+
+func (x *AroundMeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AroundMeRequest.ProtoReflect.Descriptor instead.
+func (*AroundMeRequest) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AroundMeRequest) GetLeaderboard() string {
+	if x != nil {
+		return x.Leaderboard
+	}
+	return ""
+}
+
+func (x *AroundMeRequest) GetMember() string {
+	if x != nil {
+		return x.Member
+	}
+	return ""
+}
+
+func (x *AroundMeRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type RankMembersRequest_Entry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        string                 `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RankMembersRequest_Entry) Reset() {
+	*x = RankMembersRequest_Entry{}
+	mi := &file_leaderboard_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankMembersRequest_Entry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankMembersRequest_Entry) ProtoMessage() {}
+
+func (x *RankMembersRequest_Entry) ProtoReflect() protoreflect.Message {
+	mi := &file_leaderboard_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankMembersRequest_Entry.ProtoReflect.Descriptor instead.
+func (*RankMembersRequest_Entry) Descriptor() ([]byte, []int) {
+	return file_leaderboard_proto_rawDescGZIP(), []int{5, 0}
+}
+
+func (x *RankMembersRequest_Entry) GetMember() string {
+	if x != nil {
+		return x.Member
+	}
+	return ""
+}
+
+func (x *RankMembersRequest_Entry) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+var File_leaderboard_proto protoreflect.FileDescriptor
+
+const file_leaderboard_proto_rawDesc = "" +
+	"\n" +
+	"\x11leaderboard.proto\x12\arank.v1\x1a\x1bgoogle/protobuf/empty.proto\"`\n" +
+	"\bStanding\x12\x16\n" +
+	"\x06member\x18\x01 \x01(\tR\x06member\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12\x12\n" +
+	"\x04rank\x18\x03 \x01(\x05R\x04rank\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\tR\x04data\"z\n" +
+	"\x04Page\x12+\n" +
+	"\amembers\x18\x01 \x03(\v2\x11.rank.v1.StandingR\amembers\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"6\n" +
+	"\x12LeaderboardRequest\x12 \n" +
+	"\vleaderboard\x18\x01 \x01(\tR\vleaderboard\"I\n" +
+	"\rMemberRequest\x12 \n" +
+	"\vleaderboard\x18\x01 \x01(\tR\vleaderboard\x12\x16\n" +
+	"\x06member\x18\x02 \x01(\tR\x06member\"\x92\x01\n" +
+	"\x11RankMemberRequest\x12 \n" +
+	"\vleaderboard\x18\x01 \x01(\tR\vleaderboard\x12\x16\n" +
+	"\x06member\x18\x02 \x01(\tR\x06member\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x01R\x05score\x12-\n" +
+	"\x06policy\x18\x04 \x01(\x0e2\x15.rank.v1.UpdatePolicyR\x06policy\"\xaa\x01\n" +
+	"\x12RankMembersRequest\x12 \n" +
+	"\vleaderboard\x18\x01 \x01(\tR\vleaderboard\x12;\n" +
+	"\amembers\x18\x02 \x03(\v2!.rank.v1.RankMembersRequest.EntryR\amembers\x1a5\n" +
+	"\x05Entry\x12\x16\n" +
+	"\x06member\x18\x01 \x01(\tR\x06member\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\"d\n" +
+	"\x12ChangeScoreRequest\x12 \n" +
+	"\vleaderboard\x18\x01 \x01(\tR\vleaderboard\x12\x16\n" +
+	"\x06member\x18\x02 \x01(\tR\x06member\x12\x14\n" +
+	"\x05delta\x18\x03 \x01(\x01R\x05delta\"4\n" +
+	"\x12PercentileResponse\x12\x1e\n" +
+	"\n" +
+	"percentile\x18\x01 \x01(\x05R\n" +
+	"percentile\",\n" +
+	"\x14TotalMembersResponse\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x05R\x05total\"c\n" +
+	"\x0eMembersRequest\x12 \n" +
+	"\vleaderboard\x18\x01 \x01(\tR\vleaderboard\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"<\n" +
+	"\n" +
+	"TopRequest\x12 \n" +
+	"\vleaderboard\x18\x01 \x01(\tR\vleaderboard\x12\f\n" +
+	"\x01n\x18\x02 \x01(\x05R\x01n\"X\n" +
+	"\x10RankRangeRequest\x12 \n" +
+	"\vleaderboard\x18\x01 \x01(\tR\vleaderboard\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\x05R\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\x05R\x02to\"Y\n" +
+	"\x11ScoreRangeRequest\x12 \n" +
+	"\vleaderboard\x18\x01 \x01(\tR\vleaderboard\x12\x10\n" +
+	"\x03min\x18\x02 \x01(\x01R\x03min\x12\x10\n" +
+	"\x03max\x18\x03 \x01(\x01R\x03max\"h\n" +
+	"\x0fAroundMeRequest\x12 \n" +
+	"\vleaderboard\x18\x01 \x01(\tR\vleaderboard\x12\x16\n" +
+	"\x06member\x18\x02 \x01(\tR\x06member\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize*\x93\x01\n" +
+	"\fUpdatePolicy\x12\x1d\n" +
+	"\x19UPDATE_POLICY_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15UPDATE_POLICY_REPLACE\x10\x01\x12\x1b\n" +
+	"\x17UPDATE_POLICY_INCREMENT\x10\x02\x12\x15\n" +
+	"\x11UPDATE_POLICY_MAX\x10\x03\x12\x15\n" +
+	"\x11UPDATE_POLICY_MIN\x10\x042\xcb\x06\n" +
+	"\vLeaderboard\x12;\n" +
+	"\n" +
+	"RankMember\x12\x1a.rank.v1.RankMemberRequest\x1a\x11.rank.v1.Standing\x12B\n" +
+	"\vRankMembers\x12\x1b.rank.v1.RankMembersRequest\x1a\x16.google.protobuf.Empty\x12=\n" +
+	"\vChangeScore\x12\x1b.rank.v1.ChangeScoreRequest\x1a\x11.rank.v1.Standing\x12>\n" +
+	"\fRemoveMember\x12\x16.rank.v1.MemberRequest\x1a\x16.google.protobuf.Empty\x129\n" +
+	"\fScoreAndRank\x12\x16.rank.v1.MemberRequest\x1a\x11.rank.v1.Standing\x12A\n" +
+	"\n" +
+	"Percentile\x12\x16.rank.v1.MemberRequest\x1a\x1b.rank.v1.PercentileResponse\x12J\n" +
+	"\fTotalMembers\x12\x1b.rank.v1.LeaderboardRequest\x1a\x1d.rank.v1.TotalMembersResponse\x121\n" +
+	"\aMembers\x12\x17.rank.v1.MembersRequest\x1a\r.rank.v1.Page\x12)\n" +
+	"\x03Top\x12\x13.rank.v1.TopRequest\x1a\r.rank.v1.Page\x125\n" +
+	"\tRankRange\x12\x19.rank.v1.RankRangeRequest\x1a\r.rank.v1.Page\x127\n" +
+	"\n" +
+	"ScoreRange\x12\x1a.rank.v1.ScoreRangeRequest\x1a\r.rank.v1.Page\x123\n" +
+	"\bAroundMe\x12\x18.rank.v1.AroundMeRequest\x1a\r.rank.v1.Page\x12=\n" +
+	"\x06Delete\x12\x1b.rank.v1.LeaderboardRequest\x1a\x16.google.protobuf.Empty\x120\n" +
+	"\bWatchTop\x12\x13.rank.v1.TopRequest\x1a\r.rank.v1.Page0\x01B&Z$github.com/jaksal/leaderboard/rankpbb\x06proto3"
+
+var (
+	file_leaderboard_proto_rawDescOnce sync.Once
+	file_leaderboard_proto_rawDescData []byte
+)
+
+func file_leaderboard_proto_rawDescGZIP() []byte {
+	file_leaderboard_proto_rawDescOnce.Do(func() {
+		file_leaderboard_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_leaderboard_proto_rawDesc), len(file_leaderboard_proto_rawDesc)))
+	})
+	return file_leaderboard_proto_rawDescData
+}
+
+var file_leaderboard_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_leaderboard_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_leaderboard_proto_goTypes = []any{
+	(UpdatePolicy)(0),                // 0: rank.v1.UpdatePolicy
+	(*Standing)(nil),                 // 1: rank.v1.Standing
+	(*Page)(nil),                     // 2: rank.v1.Page
+	(*LeaderboardRequest)(nil),       // 3: rank.v1.LeaderboardRequest
+	(*MemberRequest)(nil),            // 4: rank.v1.MemberRequest
+	(*RankMemberRequest)(nil),        // 5: rank.v1.RankMemberRequest
+	(*RankMembersRequest)(nil),       // 6: rank.v1.RankMembersRequest
+	(*ChangeScoreRequest)(nil),       // 7: rank.v1.ChangeScoreRequest
+	(*PercentileResponse)(nil),       // 8: rank.v1.PercentileResponse
+	(*TotalMembersResponse)(nil),     // 9: rank.v1.TotalMembersResponse
+	(*MembersRequest)(nil),           // 10: rank.v1.MembersRequest
+	(*TopRequest)(nil),               // 11: rank.v1.TopRequest
+	(*RankRangeRequest)(nil),         // 12: rank.v1.RankRangeRequest
+	(*ScoreRangeRequest)(nil),        // 13: rank.v1.ScoreRangeRequest
+	(*AroundMeRequest)(nil),          // 14: rank.v1.AroundMeRequest
+	(*RankMembersRequest_Entry)(nil), // 15: rank.v1.RankMembersRequest.Entry
+	(*emptypb.Empty)(nil),            // 16: google.protobuf.Empty
+}
+var file_leaderboard_proto_depIdxs = []int32{
+	1,  // 0: rank.v1.Page.members:type_name -> rank.v1.Standing
+	0,  // 1: rank.v1.RankMemberRequest.policy:type_name -> rank.v1.UpdatePolicy
+	15, // 2: rank.v1.RankMembersRequest.members:type_name -> rank.v1.RankMembersRequest.Entry
+	5,  // 3: rank.v1.Leaderboard.RankMember:input_type -> rank.v1.RankMemberRequest
+	6,  // 4: rank.v1.Leaderboard.RankMembers:input_type -> rank.v1.RankMembersRequest
+	7,  // 5: rank.v1.Leaderboard.ChangeScore:input_type -> rank.v1.ChangeScoreRequest
+	4,  // 6: rank.v1.Leaderboard.RemoveMember:input_type -> rank.v1.MemberRequest
+	4,  // 7: rank.v1.Leaderboard.ScoreAndRank:input_type -> rank.v1.MemberRequest
+	4,  // 8: rank.v1.Leaderboard.Percentile:input_type -> rank.v1.MemberRequest
+	3,  // 9: rank.v1.Leaderboard.TotalMembers:input_type -> rank.v1.LeaderboardRequest
+	10, // 10: rank.v1.Leaderboard.Members:input_type -> rank.v1.MembersRequest
+	11, // 11: rank.v1.Leaderboard.Top:input_type -> rank.v1.TopRequest
+	12, // 12: rank.v1.Leaderboard.RankRange:input_type -> rank.v1.RankRangeRequest
+	13, // 13: rank.v1.Leaderboard.ScoreRange:input_type -> rank.v1.ScoreRangeRequest
+	14, // 14: rank.v1.Leaderboard.AroundMe:input_type -> rank.v1.AroundMeRequest
+	3,  // 15: rank.v1.Leaderboard.Delete:input_type -> rank.v1.LeaderboardRequest
+	11, // 16: rank.v1.Leaderboard.WatchTop:input_type -> rank.v1.TopRequest
+	1,  // 17: rank.v1.Leaderboard.RankMember:output_type -> rank.v1.Standing
+	16, // 18: rank.v1.Leaderboard.RankMembers:output_type -> google.protobuf.Empty
+	1,  // 19: rank.v1.Leaderboard.ChangeScore:output_type -> rank.v1.Standing
+	16, // 20: rank.v1.Leaderboard.RemoveMember:output_type -> google.protobuf.Empty
+	1,  // 21: rank.v1.Leaderboard.ScoreAndRank:output_type -> rank.v1.Standing
+	8,  // 22: rank.v1.Leaderboard.Percentile:output_type -> rank.v1.PercentileResponse
+	9,  // 23: rank.v1.Leaderboard.TotalMembers:output_type -> rank.v1.TotalMembersResponse
+	2,  // 24: rank.v1.Leaderboard.Members:output_type -> rank.v1.Page
+	2,  // 25: rank.v1.Leaderboard.Top:output_type -> rank.v1.Page
+	2,  // 26: rank.v1.Leaderboard.RankRange:output_type -> rank.v1.Page
+	2,  // 27: rank.v1.Leaderboard.ScoreRange:output_type -> rank.v1.Page
+	2,  // 28: rank.v1.Leaderboard.AroundMe:output_type -> rank.v1.Page
+	16, // 29: rank.v1.Leaderboard.Delete:output_type -> google.protobuf.Empty
+	2,  // 30: rank.v1.Leaderboard.WatchTop:output_type -> rank.v1.Page
+	17, // [17:31] is the sub-list for method output_type
+	3,  // [3:17] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_leaderboard_proto_init() }
+func file_leaderboard_proto_init() {
+	if File_leaderboard_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_leaderboard_proto_rawDesc), len(file_leaderboard_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_leaderboard_proto_goTypes,
+		DependencyIndexes: file_leaderboard_proto_depIdxs,
+		EnumInfos:         file_leaderboard_proto_enumTypes,
+		MessageInfos:      file_leaderboard_proto_msgTypes,
+	}.Build()
+	File_leaderboard_proto = out.File
+	file_leaderboard_proto_goTypes = nil
+	file_leaderboard_proto_depIdxs = nil
+}
@@ -0,0 +1,656 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: leaderboard.proto
+
+// leaderboard operations of package rank over grpc. see package rankgrpc for the server.
+
+package rankpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Leaderboard_RankMember_FullMethodName   = "/rank.v1.Leaderboard/RankMember"
+	Leaderboard_RankMembers_FullMethodName  = "/rank.v1.Leaderboard/RankMembers"
+	Leaderboard_ChangeScore_FullMethodName  = "/rank.v1.Leaderboard/ChangeScore"
+	Leaderboard_RemoveMember_FullMethodName = "/rank.v1.Leaderboard/RemoveMember"
+	Leaderboard_ScoreAndRank_FullMethodName = "/rank.v1.Leaderboard/ScoreAndRank"
+	Leaderboard_Percentile_FullMethodName   = "/rank.v1.Leaderboard/Percentile"
+	Leaderboard_TotalMembers_FullMethodName = "/rank.v1.Leaderboard/TotalMembers"
+	Leaderboard_Members_FullMethodName      = "/rank.v1.Leaderboard/Members"
+	Leaderboard_Top_FullMethodName          = "/rank.v1.Leaderboard/Top"
+	Leaderboard_RankRange_FullMethodName    = "/rank.v1.Leaderboard/RankRange"
+	Leaderboard_ScoreRange_FullMethodName   = "/rank.v1.Leaderboard/ScoreRange"
+	Leaderboard_AroundMe_FullMethodName     = "/rank.v1.Leaderboard/AroundMe"
+	Leaderboard_Delete_FullMethodName       = "/rank.v1.Leaderboard/Delete"
+	Leaderboard_WatchTop_FullMethodName     = "/rank.v1.Leaderboard/WatchTop"
+)
+
+// LeaderboardClient is the client API for Leaderboard service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Leaderboard : operations on the leaderboards of a server, selected by name.
+// a member missing from the leaderboard is NOT_FOUND and a bad page or range is INVALID_ARGUMENT.
+type LeaderboardClient interface {
+	// RankMember : rank a member, applying policy to the stored score when given.
+	RankMember(ctx context.Context, in *RankMemberRequest, opts ...grpc.CallOption) (*Standing, error)
+	// RankMembers : rank several members at once.
+	RankMembers(ctx context.Context, in *RankMembersRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ChangeScore : add delta to the score of a member.
+	ChangeScore(ctx context.Context, in *ChangeScoreRequest, opts ...grpc.CallOption) (*Standing, error)
+	// RemoveMember : remove a member.
+	RemoveMember(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ScoreAndRank : score and rank of a member.
+	ScoreAndRank(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*Standing, error)
+	// Percentile : percentile of a member.
+	Percentile(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*PercentileResponse, error)
+	// TotalMembers : number of members.
+	TotalMembers(ctx context.Context, in *LeaderboardRequest, opts ...grpc.CallOption) (*TotalMembersResponse, error)
+	// Members : a page of members.
+	Members(ctx context.Context, in *MembersRequest, opts ...grpc.CallOption) (*Page, error)
+	// Top : the members ranked 1 to n.
+	Top(ctx context.Context, in *TopRequest, opts ...grpc.CallOption) (*Page, error)
+	// RankRange : the members ranked from..to.
+	RankRange(ctx context.Context, in *RankRangeRequest, opts ...grpc.CallOption) (*Page, error)
+	// ScoreRange : the members scored min..max.
+	ScoreRange(ctx context.Context, in *ScoreRangeRequest, opts ...grpc.CallOption) (*Page, error)
+	// AroundMe : a page of members around a member.
+	AroundMe(ctx context.Context, in *AroundMeRequest, opts ...grpc.CallOption) (*Page, error)
+	// Delete : delete the leaderboard.
+	Delete(ctx context.Context, in *LeaderboardRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// WatchTop : the top n now, then again each time it changes, until the call is canceled.
+	WatchTop(ctx context.Context, in *TopRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Page], error)
+}
+
+type leaderboardClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLeaderboardClient(cc grpc.ClientConnInterface) LeaderboardClient {
+	return &leaderboardClient{cc}
+}
+
+func (c *leaderboardClient) RankMember(ctx context.Context, in *RankMemberRequest, opts ...grpc.CallOption) (*Standing, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Standing)
+	err := c.cc.Invoke(ctx, Leaderboard_RankMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardClient) RankMembers(ctx context.Context, in *RankMembersRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Leaderboard_RankMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardClient) ChangeScore(ctx context.Context, in *ChangeScoreRequest, opts ...grpc.CallOption) (*Standing, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Standing)
+	err := c.cc.Invoke(ctx, Leaderboard_ChangeScore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardClient) RemoveMember(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Leaderboard_RemoveMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardClient) ScoreAndRank(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*Standing, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Standing)
+	err := c.cc.Invoke(ctx, Leaderboard_ScoreAndRank_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardClient) Percentile(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*PercentileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PercentileResponse)
+	err := c.cc.Invoke(ctx, Leaderboard_Percentile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardClient) TotalMembers(ctx context.Context, in *LeaderboardRequest, opts ...grpc.CallOption) (*TotalMembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TotalMembersResponse)
+	err := c.cc.Invoke(ctx, Leaderboard_TotalMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardClient) Members(ctx context.Context, in *MembersRequest, opts ...grpc.CallOption) (*Page, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Page)
+	err := c.cc.Invoke(ctx, Leaderboard_Members_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardClient) Top(ctx context.Context, in *TopRequest, opts ...grpc.CallOption) (*Page, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Page)
+	err := c.cc.Invoke(ctx, Leaderboard_Top_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardClient) RankRange(ctx context.Context, in *RankRangeRequest, opts ...grpc.CallOption) (*Page, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Page)
+	err := c.cc.Invoke(ctx, Leaderboard_RankRange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardClient) ScoreRange(ctx context.Context, in *ScoreRangeRequest, opts ...grpc.CallOption) (*Page, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Page)
+	err := c.cc.Invoke(ctx, Leaderboard_ScoreRange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardClient) AroundMe(ctx context.Context, in *AroundMeRequest, opts ...grpc.CallOption) (*Page, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Page)
+	err := c.cc.Invoke(ctx, Leaderboard_AroundMe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardClient) Delete(ctx context.Context, in *LeaderboardRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Leaderboard_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *leaderboardClient) WatchTop(ctx context.Context, in *TopRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Page], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Leaderboard_ServiceDesc.Streams[0], Leaderboard_WatchTop_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TopRequest, Page]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Leaderboard_WatchTopClient = grpc.ServerStreamingClient[Page]
+
+// LeaderboardServer is the server API for Leaderboard service.
+// All implementations must embed UnimplementedLeaderboardServer
+// for forward compatibility.
+//
+// Leaderboard : operations on the leaderboards of a server, selected by name.
+// a member missing from the leaderboard is NOT_FOUND and a bad page or range is INVALID_ARGUMENT.
+type LeaderboardServer interface {
+	// RankMember : rank a member, applying policy to the stored score when given.
+	RankMember(context.Context, *RankMemberRequest) (*Standing, error)
+	// RankMembers : rank several members at once.
+	RankMembers(context.Context, *RankMembersRequest) (*emptypb.Empty, error)
+	// ChangeScore : add delta to the score of a member.
+	ChangeScore(context.Context, *ChangeScoreRequest) (*Standing, error)
+	// RemoveMember : remove a member.
+	RemoveMember(context.Context, *MemberRequest) (*emptypb.Empty, error)
+	// ScoreAndRank : score and rank of a member.
+	ScoreAndRank(context.Context, *MemberRequest) (*Standing, error)
+	// Percentile : percentile of a member.
+	Percentile(context.Context, *MemberRequest) (*PercentileResponse, error)
+	// TotalMembers : number of members.
+	TotalMembers(context.Context, *LeaderboardRequest) (*TotalMembersResponse, error)
+	// Members : a page of members.
+	Members(context.Context, *MembersRequest) (*Page, error)
+	// Top : the members ranked 1 to n.
+	Top(context.Context, *TopRequest) (*Page, error)
+	// RankRange : the members ranked from..to.
+	RankRange(context.Context, *RankRangeRequest) (*Page, error)
+	// ScoreRange : the members scored min..max.
+	ScoreRange(context.Context, *ScoreRangeRequest) (*Page, error)
+	// AroundMe : a page of members around a member.
+	AroundMe(context.Context, *AroundMeRequest) (*Page, error)
+	// Delete : delete the leaderboard.
+	Delete(context.Context, *LeaderboardRequest) (*emptypb.Empty, error)
+	// WatchTop : the top n now, then again each time it changes, until the call is canceled.
+	WatchTop(*TopRequest, grpc.ServerStreamingServer[Page]) error
+	mustEmbedUnimplementedLeaderboardServer()
+}
+
+// UnimplementedLeaderboardServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLeaderboardServer struct{}
+
+func (UnimplementedLeaderboardServer) RankMember(context.Context, *RankMemberRequest) (*Standing, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RankMember not implemented")
+}
+func (UnimplementedLeaderboardServer) RankMembers(context.Context, *RankMembersRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RankMembers not implemented")
+}
+func (UnimplementedLeaderboardServer) ChangeScore(context.Context, *ChangeScoreRequest) (*Standing, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeScore not implemented")
+}
+func (UnimplementedLeaderboardServer) RemoveMember(context.Context, *MemberRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveMember not implemented")
+}
+func (UnimplementedLeaderboardServer) ScoreAndRank(context.Context, *MemberRequest) (*Standing, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScoreAndRank not implemented")
+}
+func (UnimplementedLeaderboardServer) Percentile(context.Context, *MemberRequest) (*PercentileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Percentile not implemented")
+}
+func (UnimplementedLeaderboardServer) TotalMembers(context.Context, *LeaderboardRequest) (*TotalMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TotalMembers not implemented")
+}
+func (UnimplementedLeaderboardServer) Members(context.Context, *MembersRequest) (*Page, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Members not implemented")
+}
+func (UnimplementedLeaderboardServer) Top(context.Context, *TopRequest) (*Page, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Top not implemented")
+}
+func (UnimplementedLeaderboardServer) RankRange(context.Context, *RankRangeRequest) (*Page, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RankRange not implemented")
+}
+func (UnimplementedLeaderboardServer) ScoreRange(context.Context, *ScoreRangeRequest) (*Page, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScoreRange not implemented")
+}
+func (UnimplementedLeaderboardServer) AroundMe(context.Context, *AroundMeRequest) (*Page, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AroundMe not implemented")
+}
+func (UnimplementedLeaderboardServer) Delete(context.Context, *LeaderboardRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedLeaderboardServer) WatchTop(*TopRequest, grpc.ServerStreamingServer[Page]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchTop not implemented")
+}
+func (UnimplementedLeaderboardServer) mustEmbedUnimplementedLeaderboardServer() {}
+func (UnimplementedLeaderboardServer) testEmbeddedByValue()                     {}
+
+// UnsafeLeaderboardServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LeaderboardServer will
+// result in compilation errors.
+type UnsafeLeaderboardServer interface {
+	mustEmbedUnimplementedLeaderboardServer()
+}
+
+func RegisterLeaderboardServer(s grpc.ServiceRegistrar, srv LeaderboardServer) {
+	// If the following call pancis, it indicates UnimplementedLeaderboardServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Leaderboard_ServiceDesc, srv)
+}
+
+func _Leaderboard_RankMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RankMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServer).RankMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Leaderboard_RankMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServer).RankMember(ctx, req.(*RankMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leaderboard_RankMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RankMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServer).RankMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Leaderboard_RankMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServer).RankMembers(ctx, req.(*RankMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leaderboard_ChangeScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeScoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServer).ChangeScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Leaderboard_ChangeScore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServer).ChangeScore(ctx, req.(*ChangeScoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leaderboard_RemoveMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServer).RemoveMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Leaderboard_RemoveMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServer).RemoveMember(ctx, req.(*MemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leaderboard_ScoreAndRank_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServer).ScoreAndRank(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Leaderboard_ScoreAndRank_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServer).ScoreAndRank(ctx, req.(*MemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leaderboard_Percentile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServer).Percentile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Leaderboard_Percentile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServer).Percentile(ctx, req.(*MemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leaderboard_TotalMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServer).TotalMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Leaderboard_TotalMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServer).TotalMembers(ctx, req.(*LeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leaderboard_Members_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServer).Members(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Leaderboard_Members_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServer).Members(ctx, req.(*MembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leaderboard_Top_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServer).Top(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Leaderboard_Top_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServer).Top(ctx, req.(*TopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leaderboard_RankRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RankRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServer).RankRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Leaderboard_RankRange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServer).RankRange(ctx, req.(*RankRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leaderboard_ScoreRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScoreRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServer).ScoreRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Leaderboard_ScoreRange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServer).ScoreRange(ctx, req.(*ScoreRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leaderboard_AroundMe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AroundMeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServer).AroundMe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Leaderboard_AroundMe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServer).AroundMe(ctx, req.(*AroundMeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leaderboard_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LeaderboardServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Leaderboard_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LeaderboardServer).Delete(ctx, req.(*LeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Leaderboard_WatchTop_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TopRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LeaderboardServer).WatchTop(m, &grpc.GenericServerStream[TopRequest, Page]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Leaderboard_WatchTopServer = grpc.ServerStreamingServer[Page]
+
+// Leaderboard_ServiceDesc is the grpc.ServiceDesc for Leaderboard service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Leaderboard_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rank.v1.Leaderboard",
+	HandlerType: (*LeaderboardServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RankMember",
+			Handler:    _Leaderboard_RankMember_Handler,
+		},
+		{
+			MethodName: "RankMembers",
+			Handler:    _Leaderboard_RankMembers_Handler,
+		},
+		{
+			MethodName: "ChangeScore",
+			Handler:    _Leaderboard_ChangeScore_Handler,
+		},
+		{
+			MethodName: "RemoveMember",
+			Handler:    _Leaderboard_RemoveMember_Handler,
+		},
+		{
+			MethodName: "ScoreAndRank",
+			Handler:    _Leaderboard_ScoreAndRank_Handler,
+		},
+		{
+			MethodName: "Percentile",
+			Handler:    _Leaderboard_Percentile_Handler,
+		},
+		{
+			MethodName: "TotalMembers",
+			Handler:    _Leaderboard_TotalMembers_Handler,
+		},
+		{
+			MethodName: "Members",
+			Handler:    _Leaderboard_Members_Handler,
+		},
+		{
+			MethodName: "Top",
+			Handler:    _Leaderboard_Top_Handler,
+		},
+		{
+			MethodName: "RankRange",
+			Handler:    _Leaderboard_RankRange_Handler,
+		},
+		{
+			MethodName: "ScoreRange",
+			Handler:    _Leaderboard_ScoreRange_Handler,
+		},
+		{
+			MethodName: "AroundMe",
+			Handler:    _Leaderboard_AroundMe_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _Leaderboard_Delete_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchTop",
+			Handler:       _Leaderboard_WatchTop_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "leaderboard.proto",
+}
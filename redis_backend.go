@@ -0,0 +1,939 @@
+package rank
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// redisBackend : Backend on top of a redis connection pool.
+type redisBackend struct {
+	pool *redis.Pool
+}
+
+// NewRedisBackend : create a Backend using redis sorted sets.
+func NewRedisBackend(pool *redis.Pool) Backend {
+	return &redisBackend{pool: pool}
+}
+
+func (b *redisBackend) do(ctx context.Context, cmd string, args ...interface{}) (interface{}, error) {
+	conn, err := b.pool.GetContext(ctx)
+	if err != nil {
+		return nil, contextErr(ctx, err)
+	}
+	defer conn.Close()
+
+	reply, err := redis.DoContext(conn, ctx, cmd, args...)
+	return reply, contextErr(ctx, err)
+}
+
+func (b *redisBackend) eval(ctx context.Context, script *redis.Script, keysAndArgs ...interface{}) (interface{}, error) {
+	conn, err := b.pool.GetContext(ctx)
+	if err != nil {
+		return nil, contextErr(ctx, err)
+	}
+	defer conn.Close()
+
+	reply, err := script.DoContext(ctx, conn, keysAndArgs...)
+	return reply, contextErr(ctx, err)
+}
+
+// pipeline : queue the commands of send on one connection and read all the replies in one round trip.
+// error replies are returned in place like the other replies.
+func (b *redisBackend) pipeline(ctx context.Context, send func(conn redis.Conn) error) ([]interface{}, error) {
+	conn, err := b.pool.GetContext(ctx)
+	if err != nil {
+		return nil, contextErr(ctx, err)
+	}
+	defer conn.Close()
+
+	if err := send(conn); err != nil {
+		return nil, err
+	}
+	// an empty command flushes the queue and returns the pending replies.
+	replies, err := redis.Values(redis.DoContext(conn, ctx, ""))
+	return replies, contextErr(ctx, err)
+}
+
+// contextErr : report ctx.Err() when a command failed because ctx was cancelled or timed out.
+func contextErr(ctx context.Context, err error) error {
+	if err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// entries : convert a WITHSCORES reply to entries.
+func entries(reply interface{}, err error) ([]Entry, error) {
+	values, err := redis.Values(reply, err)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response format: ["Alice", "123", "Bob", "456"] (i.e. flat list, not member/score tuples)
+	res := make([]Entry, 0, len(values)/2)
+	for i := 0; i+1 < len(values); i += 2 {
+		member, err := redis.String(values[i], nil)
+		if err != nil {
+			return nil, err
+		}
+		score, err := redis.Float64(values[i+1], nil)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, Entry{Member: member, Score: score})
+	}
+	return res, nil
+}
+
+func (b *redisBackend) ZAdd(ctx context.Context, key string, entries ...Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	args := redis.Args{}.Add(key)
+	for _, entry := range entries {
+		args = args.Add(entry.Score, entry.Member)
+	}
+	_, err := b.do(ctx, "ZADD", args...)
+	return err
+}
+
+func (b *redisBackend) ZAddEach(ctx context.Context, key string, entries []Entry, chunkSize int, atomic bool) ([]AddResult, error) {
+	results := make([]AddResult, len(entries))
+	if len(entries) == 0 {
+		return results, nil
+	}
+	if chunkSize < 1 {
+		chunkSize = len(entries)
+	}
+
+	// fail : report err for the entries from start on, which were not applied.
+	fail := func(start int, err error) ([]AddResult, error) {
+		err = contextErr(ctx, err)
+		for i := start; i < len(results); i++ {
+			results[i].Err = err
+		}
+		return results, err
+	}
+
+	conn, err := b.pool.GetContext(ctx)
+	if err != nil {
+		return fail(0, err)
+	}
+	defer conn.Close()
+
+	if atomic {
+		if err := conn.Send("MULTI"); err != nil {
+			return fail(0, err)
+		}
+	}
+	for start := 0; start < len(entries); start += chunkSize {
+		end := start + chunkSize
+		if end > len(entries) {
+			end = len(entries)
+		}
+		// the old score of each entry tells whether it changed.
+		for _, entry := range entries[start:end] {
+			if err := conn.Send("ZSCORE", key, entry.Member); err != nil {
+				return fail(start, err)
+			}
+			if err := conn.Send("ZADD", key, entry.Score, entry.Member); err != nil {
+				return fail(start, err)
+			}
+		}
+
+		// an empty command flushes the queue and returns the pending replies, QUEUED in a transaction.
+		replies, err := redis.Values(redis.DoContext(conn, ctx, ""))
+		if err != nil {
+			if atomic {
+				start = 0
+			}
+			return fail(start, err)
+		}
+		if atomic {
+			for _, reply := range replies {
+				if e, ok := reply.(redis.Error); ok {
+					conn.Do("DISCARD")
+					return fail(0, e)
+				}
+			}
+			continue
+		}
+		for i := 0; i+1 < len(replies); i += 2 {
+			results[start+i/2] = addResult(replies[i], replies[i+1], entries[start+i/2].Score)
+		}
+	}
+	if !atomic {
+		return results, nil
+	}
+
+	replies, err := redis.Values(redis.DoContext(conn, ctx, "EXEC"))
+	if err != nil {
+		return fail(0, err)
+	}
+	for i := 0; i+1 < len(replies); i += 2 {
+		results[i/2] = addResult(replies[i], replies[i+1], entries[i/2].Score)
+	}
+	return results, nil
+}
+
+// addResult : AddResult of the ZSCORE reply before a ZADD of score and of the ZADD reply.
+func addResult(old interface{}, reply interface{}, score float64) AddResult {
+	if err, ok := reply.(redis.Error); ok {
+		return AddResult{Err: err}
+	}
+	added, err := redis.Int(reply, nil)
+	if err != nil || added > 0 {
+		return AddResult{Added: added > 0, Changed: added > 0, Err: err}
+	}
+	oldScore, err := redis.Float64(old, nil)
+	return AddResult{Changed: oldScore != score, Err: err}
+}
+
+func (b *redisBackend) ZIncrBy(ctx context.Context, key string, member string, delta float64) (float64, error) {
+	return redis.Float64(b.do(ctx, "ZINCRBY", key, delta, member))
+}
+
+// zupdateScript : apply a batch of scores with an update policy and rank the results in one step.
+// KEYS[1] leaderboard, ARGV[1] policy, ARGV[2..] score member pairs.
+// returns {score, changed, higher, lower, ...} for every pair.
+var zupdateScript = redis.NewScript(1, `
+local key = KEYS[1]
+local policy = ARGV[1]
+local members = {}
+local changes = {}
+for i = 2, #ARGV, 2 do
+	local score = tonumber(ARGV[i])
+	local member = ARGV[i + 1]
+	local old = redis.call('ZSCORE', key, member)
+	local changed = 0
+	if policy == 'increment' then
+		if not old or score ~= 0 then
+			changed = 1
+		end
+		redis.call('ZINCRBY', key, ARGV[i], member)
+	elseif not old or (policy == 'replace' and score ~= tonumber(old))
+		or (policy == 'max' and score > tonumber(old))
+		or (policy == 'min' and score < tonumber(old)) then
+		changed = 1
+		redis.call('ZADD', key, ARGV[i], member)
+	end
+	table.insert(members, member)
+	table.insert(changes, changed)
+end
+
+local res = {}
+for i, member in ipairs(members) do
+	local score = redis.call('ZSCORE', key, member)
+	table.insert(res, score)
+	table.insert(res, changes[i])
+	table.insert(res, redis.call('ZCOUNT', key, '(' .. score, '+inf'))
+	table.insert(res, redis.call('ZCOUNT', key, '-inf', '(' .. score))
+end
+return res
+`)
+
+func (b *redisBackend) ZUpdate(ctx context.Context, key string, policy UpdatePolicy, entries ...Entry) ([]ScoreUpdate, error) {
+	if len(entries) == 0 {
+		return []ScoreUpdate{}, nil
+	}
+
+	args := redis.Args{}.Add(key, string(policy))
+	for _, entry := range entries {
+		args = args.Add(entry.Score, entry.Member)
+	}
+
+	values, err := redis.Values(b.eval(ctx, zupdateScript, args...))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]ScoreUpdate, 0, len(entries))
+	for i := range entries {
+		update := ScoreUpdate{Member: entries[i].Member}
+		if values, err = redis.Scan(values, &update.Score, &update.Changed, &update.Higher, &update.Lower); err != nil {
+			return nil, err
+		}
+		res = append(res, update)
+	}
+	return res, nil
+}
+
+func (b *redisBackend) ZRem(ctx context.Context, key string, members ...string) (int, error) {
+	return redis.Int(b.do(ctx, "ZREM", redis.Args{}.Add(key).AddFlat(members)...))
+}
+
+func (b *redisBackend) ZScore(ctx context.Context, key string, member string) (float64, bool, error) {
+	score, err := redis.Float64(b.do(ctx, "ZSCORE", key, member))
+	if err == redis.ErrNil {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return score, true, nil
+}
+
+func (b *redisBackend) ZMScore(ctx context.Context, key string, members ...string) ([]float64, []bool, error) {
+	scores, found := make([]float64, len(members)), make([]bool, len(members))
+	if len(members) == 0 {
+		return scores, found, nil
+	}
+
+	replies, err := b.pipeline(ctx, func(conn redis.Conn) error {
+		for _, member := range members {
+			if err := conn.Send("ZSCORE", key, member); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	for i, reply := range replies {
+		if reply == nil {
+			continue
+		}
+		if scores[i], err = redis.Float64(reply, nil); err != nil {
+			return nil, nil, err
+		}
+		found[i] = true
+	}
+	return scores, found, nil
+}
+
+func (b *redisBackend) ZCard(ctx context.Context, key string) (int, error) {
+	return redis.Int(b.do(ctx, "ZCARD", key))
+}
+
+func (b *redisBackend) ZCount(ctx context.Context, key string, min string, max string) (int, error) {
+	return redis.Int(b.do(ctx, "ZCOUNT", key, min, max))
+}
+
+// zcountDistinctScript : KEYS[1] leaderboard, ARGV[1] min, ARGV[2] max.
+var zcountDistinctScript = redis.NewScript(1, `
+local values = redis.call('ZRANGEBYSCORE', KEYS[1], ARGV[1], ARGV[2], 'WITHSCORES')
+local count = 0
+local last = nil
+for i = 2, #values, 2 do
+	if values[i] ~= last then
+		count = count + 1
+		last = values[i]
+	end
+end
+return count
+`)
+
+func (b *redisBackend) ZCountDistinct(ctx context.Context, key string, min string, max string) (int, error) {
+	return redis.Int(b.eval(ctx, zcountDistinctScript, key, min, max))
+}
+
+func (b *redisBackend) ZMCount(ctx context.Context, key string, ranges ...ScoreRange) ([]int, error) {
+	return b.counts(ctx, ranges, func(conn redis.Conn, r ScoreRange) error {
+		return conn.Send("ZCOUNT", key, r.Min, r.Max)
+	})
+}
+
+func (b *redisBackend) ZMCountDistinct(ctx context.Context, key string, ranges ...ScoreRange) ([]int, error) {
+	return b.counts(ctx, ranges, func(conn redis.Conn, r ScoreRange) error {
+		return zcountDistinctScript.Send(conn, key, r.Min, r.Max)
+	})
+}
+
+// counts : pipeline one counting command per range.
+func (b *redisBackend) counts(ctx context.Context, ranges []ScoreRange, send func(conn redis.Conn, r ScoreRange) error) ([]int, error) {
+	if len(ranges) == 0 {
+		return []int{}, nil
+	}
+
+	replies, err := b.pipeline(ctx, func(conn redis.Conn) error {
+		for _, r := range ranges {
+			if err := send(conn, r); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return redis.Ints(replies, nil)
+}
+
+func (b *redisBackend) ZRank(ctx context.Context, key string, member string) (int, bool, error) {
+	rank, err := redis.Int(b.do(ctx, "ZRANK", key, member))
+	if err == redis.ErrNil {
+		return -1, false, nil
+	}
+	if err != nil {
+		return -1, false, err
+	}
+	return rank, true, nil
+}
+
+func (b *redisBackend) ZRevRank(ctx context.Context, key string, member string) (int, bool, error) {
+	rank, err := redis.Int(b.do(ctx, "ZREVRANK", key, member))
+	if err == redis.ErrNil {
+		return -1, false, nil
+	}
+	if err != nil {
+		return -1, false, err
+	}
+	return rank, true, nil
+}
+
+// zrankOfScript : KEYS[1] leaderboard, ARGV[1] score, ARGV[2] member, ARGV[3] reverse.
+// ties of the score are ordered by member. the ones before member are found by a binary search on their indexes,
+// ZRANGEBYSCORE with LIMIT is linear in the offset.
+var zrankOfScript = redis.NewScript(1, `
+local key, score, member, reverse = KEYS[1], ARGV[1], ARGV[2], ARGV[3] == '1'
+local range, before = 'ZRANGE', 0
+if reverse then
+	range, before = 'ZREVRANGE', redis.call('ZCOUNT', key, '(' .. score, '+inf')
+else
+	before = redis.call('ZCOUNT', key, '-inf', '(' .. score)
+end
+local lo, hi = 0, redis.call('ZCOUNT', key, score, score)
+while lo < hi do
+	local mid = math.floor((lo + hi) / 2)
+	local tie = redis.call(range, key, before + mid, before + mid)[1]
+	if (reverse and tie > member) or (not reverse and tie < member) then
+		lo = mid + 1
+	else
+		hi = mid
+	end
+end
+return before + lo
+`)
+
+func (b *redisBackend) ZRankOf(ctx context.Context, key string, score float64, member string, reverse bool) (int, error) {
+	return redis.Int(b.eval(ctx, zrankOfScript, key, scoreBound(score, false), member, reverse))
+}
+
+func (b *redisBackend) ZMRank(ctx context.Context, key string, members ...string) ([]int, []bool, error) {
+	return b.ranks(ctx, "ZRANK", key, members)
+}
+
+func (b *redisBackend) ZMRevRank(ctx context.Context, key string, members ...string) ([]int, []bool, error) {
+	return b.ranks(ctx, "ZREVRANK", key, members)
+}
+
+// ranks : pipeline cmd (ZRANK or ZREVRANK) for every member. ranks of non-existent members are -1.
+func (b *redisBackend) ranks(ctx context.Context, cmd string, key string, members []string) ([]int, []bool, error) {
+	ranks, found := make([]int, len(members)), make([]bool, len(members))
+	if len(members) == 0 {
+		return ranks, found, nil
+	}
+
+	replies, err := b.pipeline(ctx, func(conn redis.Conn) error {
+		for _, member := range members {
+			if err := conn.Send(cmd, key, member); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	for i, reply := range replies {
+		ranks[i] = -1
+		if reply == nil {
+			continue
+		}
+		if ranks[i], err = redis.Int(reply, nil); err != nil {
+			return nil, nil, err
+		}
+		found[i] = true
+	}
+	return ranks, found, nil
+}
+
+func (b *redisBackend) ZRange(ctx context.Context, key string, start int, stop int) ([]Entry, error) {
+	return entries(b.do(ctx, "ZRANGE", key, start, stop, "WITHSCORES"))
+}
+
+func (b *redisBackend) ZRevRange(ctx context.Context, key string, start int, stop int) ([]Entry, error) {
+	return entries(b.do(ctx, "ZREVRANGE", key, start, stop, "WITHSCORES"))
+}
+
+func (b *redisBackend) ZRangeByScore(ctx context.Context, key string, min string, max string) ([]Entry, error) {
+	return entries(b.do(ctx, "ZRANGEBYSCORE", key, min, max, "WITHSCORES"))
+}
+
+func (b *redisBackend) ZMRangeByScore(ctx context.Context, key string, ranges ...ScoreRange) ([][]Entry, error) {
+	if len(ranges) == 0 {
+		return [][]Entry{}, nil
+	}
+
+	replies, err := b.pipeline(ctx, func(conn redis.Conn) error {
+		for _, r := range ranges {
+			if err := conn.Send("ZRANGEBYSCORE", key, r.Min, r.Max, "WITHSCORES"); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := make([][]Entry, 0, len(replies))
+	for _, reply := range replies {
+		values, err := entries(reply, nil)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, values)
+	}
+	return res, nil
+}
+
+func (b *redisBackend) ZRevRangeByScore(ctx context.Context, key string, max string, min string) ([]Entry, error) {
+	return entries(b.do(ctx, "ZREVRANGEBYSCORE", key, max, min, "WITHSCORES"))
+}
+
+func (b *redisBackend) ZRangeByScoreLimit(ctx context.Context, key string, min string, max string, offset int, count int) ([]Entry, error) {
+	return entries(b.do(ctx, "ZRANGEBYSCORE", key, min, max, "WITHSCORES", "LIMIT", offset, count))
+}
+
+func (b *redisBackend) ZRevRangeByScoreLimit(ctx context.Context, key string, max string, min string, offset int, count int) ([]Entry, error) {
+	return entries(b.do(ctx, "ZREVRANGEBYSCORE", key, max, min, "WITHSCORES", "LIMIT", offset, count))
+}
+
+func (b *redisBackend) ZRemRangeByScore(ctx context.Context, key string, min string, max string) (int, error) {
+	return redis.Int(b.do(ctx, "ZREMRANGEBYSCORE", key, min, max))
+}
+
+// zremDueScript : KEYS[1] due set, KEYS[2] target, KEYS[3..] others, ARGV[1] max.
+var zremDueScript = redis.NewScript(-1, `
+local due = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1])
+local removed = {}
+for _, member in ipairs(due) do
+	for i = 3, #KEYS do
+		redis.call('ZREM', KEYS[i], member)
+	end
+	redis.call('ZREM', KEYS[1], member)
+	if redis.call('ZREM', KEYS[2], member) == 1 then
+		removed[#removed + 1] = member
+	end
+end
+return removed
+`)
+
+func (b *redisBackend) ZRemDue(ctx context.Context, key string, max string, target string, others ...string) ([]string, error) {
+	args := redis.Args{}.Add(2+len(others), key, target).AddFlat(others).Add(max)
+	return redis.Strings(b.eval(ctx, zremDueScript, args...))
+}
+
+// zscaleScript : KEYS[1] leaderboard, ARGV[1] half factor, applied twice.
+var zscaleScript = redis.NewScript(1, `
+local half = tonumber(ARGV[1])
+local entries = redis.call('ZRANGE', KEYS[1], 0, -1, 'WITHSCORES')
+for i = 1, #entries, 2 do
+	redis.call('ZADD', KEYS[1], string.format('%.17g', tonumber(entries[i + 1]) * half * half), entries[i])
+end
+return #entries / 2
+`)
+
+func (b *redisBackend) ZScaleExp2(ctx context.Context, key string, exp float64) error {
+	_, err := b.eval(ctx, zscaleScript, key, math.Exp2(exp/2))
+	return err
+}
+
+func (b *redisBackend) ZRemRangeByRank(ctx context.Context, key string, start int, stop int) (int, error) {
+	return redis.Int(b.do(ctx, "ZREMRANGEBYRANK", key, start, stop))
+}
+
+func (b *redisBackend) ZUnionStore(ctx context.Context, dest string, keys []string, weights []float64, aggregate Aggregate) (int, error) {
+	return redis.Int(b.do(ctx, "ZUNIONSTORE", storeArgs(dest, keys, weights, aggregate)...))
+}
+
+func (b *redisBackend) ZInterStore(ctx context.Context, dest string, keys []string, weights []float64, aggregate Aggregate) (int, error) {
+	return redis.Int(b.do(ctx, "ZINTERSTORE", storeArgs(dest, keys, weights, aggregate)...))
+}
+
+// storeArgs : arguments of ZUNIONSTORE and ZINTERSTORE.
+func storeArgs(dest string, keys []string, weights []float64, aggregate Aggregate) redis.Args {
+	args := redis.Args{}.Add(dest, len(keys)).AddFlat(keys)
+	if weights != nil {
+		args = args.Add("WEIGHTS").AddFlat(weights)
+	}
+	if aggregate != "" {
+		args = args.Add("AGGREGATE", string(aggregate))
+	}
+	return args
+}
+
+// zinterScript : KEYS[1] leaderboard, KEYS[2] plain set. ZINTER needs redis 6.2.
+var zinterScript = redis.NewScript(2, `
+local res = {}
+for _, member in ipairs(redis.call('SMEMBERS', KEYS[2])) do
+	local score = redis.call('ZSCORE', KEYS[1], member)
+	if score then
+		res[#res + 1] = member
+		res[#res + 1] = score
+	end
+end
+return res
+`)
+
+func (b *redisBackend) ZInter(ctx context.Context, key string, setKey string) ([]Entry, error) {
+	return entries(b.eval(ctx, zinterScript, key, setKey))
+}
+
+// zsumStoreScript : KEYS[1] dest, KEYS[2] src, ARGV[1] member, ARGV[2] start, ARGV[3] stop, ARGV[4] reverse.
+var zsumStoreScript = redis.NewScript(2, `
+local cmd = 'ZRANGE'
+if ARGV[4] == '1' then
+	cmd = 'ZREVRANGE'
+end
+local values = redis.call(cmd, KEYS[2], ARGV[2], ARGV[3], 'WITHSCORES')
+if #values == 0 then
+	redis.call('ZREM', KEYS[1], ARGV[1])
+	return '0'
+end
+local sum = 0
+for i = 2, #values, 2 do
+	sum = sum + tonumber(values[i])
+end
+-- numbers passed to redis.call are truncated to integers.
+sum = string.format('%.17g', sum)
+redis.call('ZADD', KEYS[1], sum, ARGV[1])
+return sum
+`)
+
+func (b *redisBackend) ZSumStore(ctx context.Context, dest string, member string, src string, start int, stop int, reverse bool) (float64, error) {
+	return redis.Float64(b.eval(ctx, zsumStoreScript, dest, src, member, start, stop, reverse))
+}
+
+// ztopStoreScript : KEYS[1] dest, KEYS[2] src, KEYS[3] timestamps, ARGV[1] n, ARGV[2] reverse, ARGV[3] mode name.
+// returns {entered, left}.
+var ztopStoreScript = redis.NewScript(3, `
+local src, n, reverse, mode = KEYS[2], tonumber(ARGV[1]), ARGV[2] == '1', ARGV[3]
+local range = 'ZRANGE'
+if reverse then
+	range = 'ZREVRANGE'
+end
+-- members scored from the best score down to edge, best first.
+local function upTo(edge)
+	if reverse then
+		return redis.call('ZREVRANGEBYSCORE', src, '+inf', edge)
+	end
+	return redis.call('ZRANGEBYSCORE', src, '-inf', edge)
+end
+
+local top = {}
+local last = redis.call(range, src, n - 1, n - 1, 'WITHSCORES')
+if #last == 0 then
+	-- fewer than n members: the top reaches the worst score.
+	last = redis.call(range, src, -1, -1, 'WITHSCORES')
+end
+if n < 1 or #last == 0 then
+	-- an empty top.
+elseif mode == 'ordinal' then
+	top = redis.call(range, src, 0, n - 1)
+elseif mode == 'dense' then
+	-- the nth distinct score from the best one.
+	local edge = redis.call(range, src, 0, 0, 'WITHSCORES')[2]
+	for i = 2, n do
+		local worse
+		if reverse then
+			worse = redis.call('ZREVRANGEBYSCORE', src, '(' .. edge, '-inf', 'WITHSCORES', 'LIMIT', 0, 1)
+		else
+			worse = redis.call('ZRANGEBYSCORE', src, '(' .. edge, '+inf', 'WITHSCORES', 'LIMIT', 0, 1)
+		end
+		if #worse == 0 then
+			break
+		end
+		edge = worse[2]
+	end
+	top = upTo(edge)
+elseif mode == 'earliest' then
+	-- the ties at the edge are ordered by submission time, then like the range commands.
+	local edge = last[2]
+	top = upTo('(' .. edge)
+	local ties = redis.call(reverse and 'ZREVRANGEBYSCORE' or 'ZRANGEBYSCORE', src, edge, edge)
+	local times = {}
+	for _, member in ipairs(ties) do
+		times[member] = redis.call('HGET', KEYS[3], member)
+	end
+	table.sort(ties, function(a, b)
+		local ta, tb = times[a], times[b]
+		if ta ~= tb then
+			-- members without a time go last. times are decimal nanoseconds.
+			if not ta or not tb then
+				return not tb
+			end
+			if #ta ~= #tb then
+				return #ta < #tb
+			end
+			return ta < tb
+		end
+		if reverse then
+			return a > b
+		end
+		return a < b
+	end)
+	for i = 1, n - #top do
+		top[#top + 1] = ties[i]
+	end
+else
+	top = upTo(last[2])
+end
+
+local entered, left, inTop = {}, {}, {}
+for _, member in ipairs(top) do
+	inTop[member] = true
+	if redis.call('SADD', KEYS[1], member) == 1 then
+		entered[#entered + 1] = member
+	end
+end
+for _, member in ipairs(redis.call('SMEMBERS', KEYS[1])) do
+	if not inTop[member] then
+		redis.call('SREM', KEYS[1], member)
+		left[#left + 1] = member
+	end
+end
+return {entered, left}
+`)
+
+func (b *redisBackend) ZTopStore(ctx context.Context, dest string, src string, n int, reverse bool, mode RankingMode, timestamps string) ([]string, []string, error) {
+	values, err := redis.Values(b.eval(ctx, ztopStoreScript, dest, src, timestamps, n, reverse, mode.String()))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var entered, left []string
+	if _, err := redis.Scan(values, &entered, &left); err != nil {
+		return nil, nil, err
+	}
+	return entered, left, nil
+}
+
+func (b *redisBackend) HSet(ctx context.Context, key string, values map[string]string) error {
+	if len(values) == 0 {
+		return nil
+	}
+	_, err := b.do(ctx, "HSET", redis.Args{}.Add(key).AddFlat(values)...)
+	return err
+}
+
+func (b *redisBackend) HMGet(ctx context.Context, key string, fields ...string) (map[string]string, error) {
+	res := make(map[string]string, len(fields))
+	if len(fields) == 0 {
+		return res, nil
+	}
+
+	values, err := redis.Values(b.do(ctx, "HMGET", redis.Args{}.Add(key).AddFlat(fields)...))
+	if err != nil {
+		return nil, err
+	}
+	for i, value := range values {
+		if value == nil {
+			continue
+		}
+		if res[fields[i]], err = redis.String(value, nil); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (b *redisBackend) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	return redis.StringMap(b.do(ctx, "HGETALL", key))
+}
+
+func (b *redisBackend) HDel(ctx context.Context, key string, fields ...string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	_, err := b.do(ctx, "HDEL", redis.Args{}.Add(key).AddFlat(fields)...)
+	return err
+}
+
+func (b *redisBackend) SAdd(ctx context.Context, key string, members ...string) error {
+	if len(members) == 0 {
+		return nil
+	}
+	_, err := b.do(ctx, "SADD", redis.Args{}.Add(key).AddFlat(members)...)
+	return err
+}
+
+func (b *redisBackend) SRem(ctx context.Context, key string, members ...string) error {
+	if len(members) == 0 {
+		return nil
+	}
+	_, err := b.do(ctx, "SREM", redis.Args{}.Add(key).AddFlat(members)...)
+	return err
+}
+
+func (b *redisBackend) SMembers(ctx context.Context, key string) ([]string, error) {
+	return redis.Strings(b.do(ctx, "SMEMBERS", key))
+}
+
+func (b *redisBackend) Del(ctx context.Context, key string) error {
+	_, err := b.do(ctx, "DEL", key)
+	return err
+}
+
+// renameNXScript : KEYS[1..] key new key pairs.
+var renameNXScript = redis.NewScript(-1, `
+for i = 2, #KEYS, 2 do
+	if redis.call('EXISTS', KEYS[i]) == 1 then
+		return 0
+	end
+end
+for i = 1, #KEYS, 2 do
+	if redis.call('EXISTS', KEYS[i]) == 1 then
+		redis.call('RENAME', KEYS[i], KEYS[i + 1])
+	end
+end
+return 1
+`)
+
+func (b *redisBackend) RenameNX(ctx context.Context, renames map[string]string) (bool, error) {
+	if len(renames) == 0 {
+		return true, nil
+	}
+
+	args := redis.Args{}.Add(len(renames) * 2)
+	for key, newKey := range renames {
+		args = args.Add(key, newKey)
+	}
+	return redis.Bool(b.eval(ctx, renameNXScript, args...))
+}
+
+func (b *redisBackend) Publish(ctx context.Context, channel string, messages ...string) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	_, err := b.pipeline(ctx, func(conn redis.Conn) error {
+		for _, message := range messages {
+			if err := conn.Send("PUBLISH", channel, message); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+func (b *redisBackend) Subscribe(ctx context.Context, channel string) (<-chan string, error) {
+	conn, err := b.pool.GetContext(ctx)
+	if err != nil {
+		return nil, contextErr(ctx, err)
+	}
+
+	psc := redis.PubSubConn{Conn: conn}
+	if err := psc.Subscribe(channel); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	// wait for the confirmation, so messages published after Subscribe returns are received.
+	switch v := psc.ReceiveContext(ctx).(type) {
+	case error:
+		conn.Close()
+		return nil, contextErr(ctx, v)
+	}
+
+	messages := make(chan string, 128)
+	go func() {
+		defer close(messages)
+		defer conn.Close()
+		for {
+			switch v := psc.ReceiveContext(ctx).(type) {
+			case redis.Message:
+				select {
+				case messages <- string(v.Data):
+				case <-ctx.Done():
+					return
+				}
+			case error:
+				return
+			}
+		}
+	}()
+	return messages, nil
+}
+
+func (b *redisBackend) XAdd(ctx context.Context, stream string, maxLen int, messages ...string) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	_, err := b.pipeline(ctx, func(conn redis.Conn) error {
+		for _, message := range messages {
+			args := redis.Args{}.Add(stream)
+			if maxLen > 0 {
+				args = args.Add("MAXLEN", "~", maxLen)
+			}
+			if err := conn.Send("XADD", args.Add("*", "message", message)...); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+func (b *redisBackend) XRead(ctx context.Context, stream string, lastID string, count int, block time.Duration) ([]StreamMessage, error) {
+	args := redis.Args{}
+	if count > 0 {
+		args = args.Add("COUNT", count)
+	}
+	if block > 0 {
+		args = args.Add("BLOCK", strconv.FormatInt(int64(block/time.Millisecond), 10))
+	}
+	streams, err := redis.Values(b.do(ctx, "XREAD", args.Add("STREAMS", stream, lastID)...))
+	if err == redis.ErrNil {
+		return []StreamMessage{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Response format: [[stream, [[id, [field, value, ...]], ...]]]
+	res := []StreamMessage{}
+	for _, s := range streams {
+		values, err := redis.Values(s, nil)
+		if err != nil {
+			return nil, err
+		}
+		var name string
+		var entries []interface{}
+		if _, err := redis.Scan(values, &name, &entries); err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			values, err := redis.Values(entry, nil)
+			if err != nil {
+				return nil, err
+			}
+			var message StreamMessage
+			var fields []string
+			if _, err := redis.Scan(values, &message.ID, &fields); err != nil {
+				return nil, err
+			}
+			for i := 0; i+1 < len(fields); i += 2 {
+				if fields[i] == "message" {
+					message.Message = fields[i+1]
+				}
+			}
+			res = append(res, message)
+		}
+	}
+	return res, nil
+}
+
+func (b *redisBackend) ExpireAt(ctx context.Context, key string, t time.Time) error {
+	_, err := b.do(ctx, "PEXPIREAT", key, t.UnixNano()/int64(time.Millisecond))
+	return err
+}
+
+func (b *redisBackend) Close() error {
+	return b.pool.Close()
+}
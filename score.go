@@ -0,0 +1,48 @@
+package rank
+
+import (
+	"math"
+)
+
+// WithPrecision : store scores as fixed-point integers with decimals digits after the point.
+// scores are rounded on write, so sums of money or times stay exact.
+func WithPrecision(decimals int) Option {
+	return func(lb *Leaderboard) {
+		lb.scale = math.Pow10(decimals)
+	}
+}
+
+// scaleFactor : multiplier from a score to its stored value.
+func (lb *Leaderboard) scaleFactor() float64 {
+	if lb.scale == 0 {
+		return 1
+	}
+	return lb.scale
+}
+
+// encode : score as stored in the backend. time-normalized under decay.
+func (lb *Leaderboard) encode(score float64) float64 {
+	if lb.decay != nil {
+		score *= lb.decay.weight(lb.now())
+	}
+	if lb.scale == 0 {
+		return score
+	}
+	return math.Round(score * lb.scale)
+}
+
+// decode : score of a stored value. decayed to now under decay.
+func (lb *Leaderboard) decode(score float64) float64 {
+	if lb.scale != 0 {
+		score /= lb.scale
+	}
+	if lb.decay != nil {
+		score /= lb.decay.weight(lb.now())
+	}
+	return score
+}
+
+// bound : range bound of score as stored in the backend.
+func (lb *Leaderboard) bound(score float64, exclusive bool) string {
+	return scoreBound(lb.encode(score), exclusive)
+}
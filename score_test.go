@@ -0,0 +1,63 @@
+package rank
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFloatScores(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	ctx := context.Background()
+	lb := NewLeaderboardWithBackend(backend, lbName)
+	defer lb.Delete(ctx)
+
+	lb.RankMember(ctx, "member_1", 12.5)
+	lb.RankMember(ctx, "member_2", 12.25)
+	lb.ChangeScoreFor(ctx, "member_2", 0.5)
+
+	// written by another service.
+	backend.ZAdd(ctx, lbName, Entry{Member: "member_3", Score: 7.75})
+
+	if score, err := lb.ScoreFor(ctx, "member_2"); err != nil || score != 12.75 {
+		t.Error("Leaderboard ScoreFor Err!", score, err)
+	}
+	if score, err := lb.ScoreFor(ctx, "member_3"); err != nil || score != 7.75 {
+		t.Error("Leaderboard ScoreFor Err!", score, err)
+	}
+	if rank, _ := lb.RankFor(ctx, "member_1"); rank != 2 {
+		t.Error("Leaderboard RankFor Err!", rank)
+	}
+	if count, _ := lb.TotalMembersInScoreRange(ctx, 7.5, 12.5); count != 2 {
+		t.Error("Leaderboard TotalMembersInScoreRange Err!", count)
+	}
+	if members, _ := lb.MembersFromScoreRange(ctx, 12.6, 13); len(members) != 1 || members[0].GetScore() != 12.75 {
+		t.Error("Leaderboard MembersFromScoreRange Err!", members)
+	}
+	if score, _ := lb.ScoreForPercentile(ctx, 25); score != 12.625 {
+		t.Error("Leaderboard ScoreForPercentile Err!", score)
+	}
+}
+
+func TestPrecision(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	ctx := context.Background()
+	lb := NewLeaderboardWithBackend(NewMemoryBackend(), lbName, WithPrecision(2))
+
+	for i := 0; i < 10; i++ {
+		lb.ChangeScoreFor(ctx, "member_1", 0.1)
+	}
+	lb.RankMember(ctx, "member_2", 0.999)
+
+	if score, _ := lb.ScoreFor(ctx, "member_1"); score != 1 {
+		t.Error("Leaderboard ScoreFor Err!", score)
+	}
+	if score, _ := lb.ScoreFor(ctx, "member_2"); score != 1 {
+		t.Error("Leaderboard ScoreFor Err!", score)
+	}
+	if rank, _ := lb.RankFor(ctx, "member_2"); rank != 1 {
+		t.Error("Leaderboard RankFor Err!", rank)
+	}
+	if count, _ := lb.TotalMembersInScoreRange(ctx, 1, 1); count != 2 {
+		t.Error("Leaderboard TotalMembersInScoreRange Err!", count)
+	}
+}
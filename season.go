@@ -0,0 +1,208 @@
+package rank
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+)
+
+// ErrSeasonArchived : Rollover was called with the ID of an archived season.
+var ErrSeasonArchived = errors.New("rank: season already archived")
+
+// Season : rollover record of a past season.
+type Season struct {
+	ID         string    `json:"id"`
+	ArchivedAt time.Time `json:"archived_at"`
+	// Members : number of members in the final standings.
+	Members int `json:"members"`
+	// Epoch : origin of the stored scores of a decaying leaderboard. nil without decay.
+	Epoch *time.Time `json:"epoch,omitempty"`
+}
+
+// seasonsKey : hash of season ID -> json Season.
+func (lb *Leaderboard) seasonsKey() string {
+	return lb.Name + ":seasons"
+}
+
+// archive : leaderboard holding the final standings of season seasonID.
+// decayed scores are read as they were when season was archived. nil season is only good for the keys.
+func (lb *Leaderboard) archive(seasonID string, season *Season) *Leaderboard {
+	archived := *lb
+	archived.Name = lb.Name + ":season:" + seasonID
+	archived.decay = nil
+	if lb.decay != nil && season != nil && season.Epoch != nil {
+		// a Rebase of the leaderboard does not move the archive.
+		archived.decay = &decay{exponential: lb.decay.exponential, period: lb.decay.period, epoch: *season.Epoch}
+		archived.now = func() time.Time { return season.ArchivedAt }
+	}
+	return &archived
+}
+
+// Rollover : End the current season. the leaderboard is moved to an archive under seasonID
+// in one atomic step and starts again empty. the season record is written after the move: a Rollover
+// that failed in between is finished by calling it again, which writes the record of the archive.
+func (lb *Leaderboard) Rollover(ctx context.Context, seasonID string) (*Season, error) {
+	archived := lb.archive(seasonID, nil)
+	renames := make(map[string]string)
+	for i, key := range lb.keys() {
+		renames[key] = archived.keys()[i]
+	}
+
+	if values, err := lb.backend.HMGet(ctx, lb.seasonsKey(), seasonID); err != nil {
+		return nil, err
+	} else if _, ok := values[seasonID]; ok {
+		return nil, ErrSeasonArchived
+	}
+	// not ok: the archive exists without its record. the live board is left alone and the record written.
+	if _, err := lb.backend.RenameNX(ctx, renames); err != nil {
+		return nil, err
+	}
+
+	members, err := archived.TotalMembers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	season := &Season{ID: seasonID, ArchivedAt: lb.now().UTC(), Members: members}
+	if lb.decay != nil {
+		epoch := lb.decay.origin()
+		season.Epoch = &epoch
+	}
+	value, err := json.Marshal(season)
+	if err != nil {
+		return nil, err
+	}
+	if err := lb.backend.HSet(ctx, lb.seasonsKey(), map[string]string{seasonID: string(value)}); err != nil {
+		return nil, err
+	}
+	return season, nil
+}
+
+// Seasons : Retrieve the past seasons of the leaderboard, oldest first.
+func (lb *Leaderboard) Seasons(ctx context.Context) ([]*Season, error) {
+	values, err := lb.backend.HGetAll(ctx, lb.seasonsKey())
+	if err != nil {
+		return nil, err
+	}
+
+	seasons := make([]*Season, 0, len(values))
+	for _, value := range values {
+		season := &Season{}
+		if err := json.Unmarshal([]byte(value), season); err != nil {
+			return nil, err
+		}
+		seasons = append(seasons, season)
+	}
+	sort.Slice(seasons, func(i, j int) bool {
+		return seasons[i].ArchivedAt.Before(seasons[j].ArchivedAt)
+	})
+	return seasons, nil
+}
+
+// Season : Retrieve the final standings of a past season. returns ErrSeasonNotFound for an unknown season.
+func (lb *Leaderboard) Season(ctx context.Context, seasonID string) (*ArchivedLeaderboard, error) {
+	values, err := lb.backend.HMGet(ctx, lb.seasonsKey(), seasonID)
+	if err != nil {
+		return nil, err
+	}
+	value, ok := values[seasonID]
+	if !ok {
+		return nil, ErrSeasonNotFound
+	}
+
+	season := &Season{}
+	if err := json.Unmarshal([]byte(value), season); err != nil {
+		return nil, err
+	}
+	return &ArchivedLeaderboard{Season: season, lb: lb.archive(seasonID, season)}, nil
+}
+
+// DeleteSeason : Delete the archive and the record of a past season.
+func (lb *Leaderboard) DeleteSeason(ctx context.Context, seasonID string) error {
+	if err := lb.archive(seasonID, nil).Delete(ctx); err != nil {
+		return err
+	}
+	return lb.backend.HDel(ctx, lb.seasonsKey(), seasonID)
+}
+
+// ArchivedLeaderboard : read only view of the final standings of a past season.
+type ArchivedLeaderboard struct {
+	Season *Season
+	lb     *Leaderboard
+}
+
+// TotalMembers : Retrieve the total number of members in the season.
+func (a *ArchivedLeaderboard) TotalMembers(ctx context.Context) (int, error) {
+	return a.lb.TotalMembers(ctx)
+}
+
+// TotalPages : Retrieve the total number of pages in the season.
+func (a *ArchivedLeaderboard) TotalPages(ctx context.Context, pageSize int) int {
+	return a.lb.TotalPages(ctx, pageSize)
+}
+
+// CheckMember : Check to see if a member exists in the season.
+func (a *ArchivedLeaderboard) CheckMember(ctx context.Context, member string) (bool, error) {
+	return a.lb.CheckMember(ctx, member)
+}
+
+// ScoreFor : Retrieve the final score for a member in the season.
+func (a *ArchivedLeaderboard) ScoreFor(ctx context.Context, member string) (float64, error) {
+	return a.lb.ScoreFor(ctx, member)
+}
+
+// RankFor : Retrieve the final rank for a member in the season.
+func (a *ArchivedLeaderboard) RankFor(ctx context.Context, member string) (int, error) {
+	return a.lb.RankFor(ctx, member)
+}
+
+// ScoreAndRankFor : Retrieve the final score and rank for a member in the season.
+func (a *ArchivedLeaderboard) ScoreAndRankFor(ctx context.Context, member string) (*RankScore, error) {
+	return a.lb.ScoreAndRankFor(ctx, member)
+}
+
+// PercentileFor : Retrieve the final percentile for a member in the season.
+func (a *ArchivedLeaderboard) PercentileFor(ctx context.Context, member string) (int, error) {
+	return a.lb.PercentileFor(ctx, member)
+}
+
+// MemberDataFor : Retrieve the member data for a member in the season.
+func (a *ArchivedLeaderboard) MemberDataFor(ctx context.Context, member string) (string, error) {
+	return a.lb.MemberDataFor(ctx, member)
+}
+
+// RankedInList : Retrieve the final standings of a given list of members.
+func (a *ArchivedLeaderboard) RankedInList(ctx context.Context, members []string) ([]*RankScore, error) {
+	return a.lb.RankedInList(ctx, members)
+}
+
+// Members : Retrieve a page of the final standings.
+func (a *ArchivedLeaderboard) Members(ctx context.Context, currentPage int, pageSize int) ([]*RankScore, error) {
+	return a.lb.Members(ctx, currentPage, pageSize)
+}
+
+// AllMembers : Retrieve all the final standings.
+func (a *ArchivedLeaderboard) AllMembers(ctx context.Context) ([]*RankScore, error) {
+	return a.lb.AllMembers(ctx)
+}
+
+// MembersFromRankRange : Retrieve the final standings within a given rank range.
+func (a *ArchivedLeaderboard) MembersFromRankRange(ctx context.Context, startingRank int, endingRank int) ([]*RankScore, error) {
+	return a.lb.MembersFromRankRange(ctx, startingRank, endingRank)
+}
+
+// MembersFromScoreRange : Retrieve the final standings within a given score range.
+func (a *ArchivedLeaderboard) MembersFromScoreRange(ctx context.Context, minimumScore float64, maximumScore float64) ([]*RankScore, error) {
+	return a.lb.MembersFromScoreRange(ctx, minimumScore, maximumScore)
+}
+
+// Top : Retrieve the final standings from rank 1 to the number given.
+func (a *ArchivedLeaderboard) Top(ctx context.Context, number int) ([]*RankScore, error) {
+	return a.lb.Top(ctx, number)
+}
+
+// AroundMe : Retrieve a page of the final standings around a given member.
+func (a *ArchivedLeaderboard) AroundMe(ctx context.Context, member string, pageSize int) ([]*RankScore, error) {
+	return a.lb.AroundMe(ctx, member, pageSize)
+}
@@ -0,0 +1,94 @@
+package rank
+
+import (
+	"context"
+	"testing"
+)
+
+func testRollover(t *testing.T, b Backend) {
+	ctx := context.Background()
+	lb := NewLeaderboardWithBackend(b, lbName, WithMemberData())
+	defer lb.Delete(ctx)
+	defer lb.DeleteSeason(ctx, "2024-s1")
+
+	lb.RankMemberWithData(ctx, "member_1", 50, "alice")
+	lb.RankMember(ctx, "member_2", 40)
+	lb.RankMember(ctx, "member_3", 30)
+
+	season, err := lb.Rollover(ctx, "2024-s1")
+	if err != nil || season.ID != "2024-s1" || season.Members != 3 {
+		t.Fatal("Leaderboard Rollover Err!", season, err)
+	}
+	if _, err := lb.Rollover(ctx, "2024-s1"); err != ErrSeasonArchived {
+		t.Error("Leaderboard Rollover Err!", err)
+	}
+
+	// the live board starts again.
+	if count, _ := lb.TotalMembers(ctx); count != 0 {
+		t.Error("Leaderboard TotalMembers Err!", count)
+	}
+	lb.RankMember(ctx, "member_3", 10)
+
+	archived, err := lb.Season(ctx, "2024-s1")
+	if err != nil || archived.Season.Members != 3 {
+		t.Fatal("Leaderboard Season Err!", archived, err)
+	}
+	if rank, _ := archived.RankFor(ctx, "member_3"); rank != 3 {
+		t.Error("ArchivedLeaderboard RankFor Err!", rank)
+	}
+	if members, _ := archived.Top(ctx, 1); len(members) != 1 || members[0].Member != "member_1" || members[0].GetData() != "alice" {
+		t.Error("ArchivedLeaderboard Top Err!", members)
+	}
+
+	if seasons, err := lb.Seasons(ctx); err != nil || len(seasons) != 1 || seasons[0].ID != "2024-s1" {
+		t.Error("Leaderboard Seasons Err!", seasons, err)
+	}
+	if _, err := lb.Season(ctx, "2023-s4"); err == nil {
+		t.Error("Leaderboard Season Err!", err)
+	}
+
+	lb.DeleteSeason(ctx, "2024-s1")
+	if seasons, _ := lb.Seasons(ctx); len(seasons) != 0 {
+		t.Error("Leaderboard DeleteSeason Err!", seasons)
+	}
+}
+
+func testRolloverRetry(t *testing.T, b Backend) {
+	ctx := context.Background()
+	lb := NewLeaderboardWithBackend(b, lbName)
+	defer lb.Delete(ctx)
+	defer lb.DeleteSeason(ctx, "2024-s1")
+
+	lb.RankMember(ctx, "member_1", 50)
+	lb.RankMember(ctx, "member_2", 40)
+
+	// a Rollover that stopped after the move, before the record.
+	b.RenameNX(ctx, map[string]string{lb.Name: lb.archive("2024-s1", nil).Name})
+	lb.RankMember(ctx, "member_3", 10)
+
+	season, err := lb.Rollover(ctx, "2024-s1")
+	if err != nil || season.Members != 2 {
+		t.Fatal("Leaderboard Rollover Retry Err!", season, err)
+	}
+	if count, _ := lb.TotalMembers(ctx); count != 1 {
+		t.Error("Leaderboard Rollover Retry TotalMembers Err!", count)
+	}
+	if archived, err := lb.Season(ctx, "2024-s1"); err != nil || archived.Season.Members != 2 {
+		t.Error("Leaderboard Rollover Retry Season Err!", archived, err)
+	}
+	if _, err := lb.Rollover(ctx, "2024-s1"); err != ErrSeasonArchived {
+		t.Error("Leaderboard Rollover Retry Err!", err)
+	}
+}
+
+func TestRolloverRetry(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	testRolloverRetry(t, backend)
+	testRolloverRetry(t, NewMemoryBackend())
+}
+
+func TestRollover(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	testRollover(t, backend)
+	testRollover(t, NewMemoryBackend())
+}
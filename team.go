@@ -0,0 +1,178 @@
+package rank
+
+import (
+	"context"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// TeamLeaderboard : individual leaderboard whose members belong to teams, with a leaderboard of the teams.
+// a team scores the sum of the scores of its members, or of its best topN members.
+// every write through Individual updates the team of the member.
+type TeamLeaderboard struct {
+	// Individual : leaderboard of the members.
+	Individual *Leaderboard
+	// Teams : leaderboard of the teams.
+	Teams   *Leaderboard
+	options []Option
+	topN    int
+}
+
+// NewTeamLeaderboard : create a team leaderboard named lbName backed by a redis pool. topN 0 sums every member.
+func NewTeamLeaderboard(pool *redis.Pool, lbName string, topN int, options ...Option) *TeamLeaderboard {
+	return NewTeamLeaderboardWithBackend(NewRedisBackend(pool), lbName, topN, options...)
+}
+
+// NewTeamLeaderboardWithBackend : create a team leaderboard named lbName stored in backend. topN 0 sums every member.
+func NewTeamLeaderboardWithBackend(backend Backend, lbName string, topN int, options ...Option) *TeamLeaderboard {
+	tl := &TeamLeaderboard{
+		Individual: NewLeaderboardWithBackend(backend, lbName, options...),
+		Teams:      NewLeaderboardWithBackend(backend, lbName+":teams", options...),
+		options:    options,
+		topN:       topN,
+	}
+	tl.Individual.hooks = append(tl.Individual.hooks, tl.sync)
+	return tl
+}
+
+// teamOfKey : hash of member -> team.
+func (tl *TeamLeaderboard) teamOfKey() string {
+	return tl.Individual.Name + ":team_of"
+}
+
+// Team : leaderboard of the members of team, for ranks within the team.
+func (tl *TeamLeaderboard) Team(team string) *Leaderboard {
+	return NewLeaderboardWithBackend(tl.Individual.backend, tl.Individual.Name+":team:"+team, tl.options...)
+}
+
+// TeamOf : Retrieve the team of member. returns ErrMemberNotFound for a member without a team.
+func (tl *TeamLeaderboard) TeamOf(ctx context.Context, member string) (string, error) {
+	values, err := tl.Individual.backend.HMGet(ctx, tl.teamOfKey(), member)
+	if err != nil {
+		return "", err
+	}
+	team, ok := values[member]
+	if !ok {
+		return "", ErrMemberNotFound
+	}
+	return team, nil
+}
+
+// JoinTeam : Move member to team. the scores of the old and the new team follow.
+func (tl *TeamLeaderboard) JoinTeam(ctx context.Context, member string, team string) error {
+	if err := tl.LeaveTeam(ctx, member); err != nil {
+		return err
+	}
+	if err := tl.Individual.backend.HSet(ctx, tl.teamOfKey(), map[string]string{member: team}); err != nil {
+		return err
+	}
+	return tl.sync(ctx, []string{member})
+}
+
+// LeaveTeam : Remove member from its team.
+func (tl *TeamLeaderboard) LeaveTeam(ctx context.Context, member string) error {
+	team, err := tl.TeamOf(ctx, member)
+	if err == ErrMemberNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := tl.Individual.backend.HDel(ctx, tl.teamOfKey(), member); err != nil {
+		return err
+	}
+	if _, err := tl.Individual.backend.ZRem(ctx, tl.Team(team).Name, member); err != nil {
+		return err
+	}
+	return tl.score(ctx, team)
+}
+
+// sync : copy the scores of members to their teams and score the teams again.
+func (tl *TeamLeaderboard) sync(ctx context.Context, members []string) error {
+	b := tl.Individual.backend
+	teams, err := b.HMGet(ctx, tl.teamOfKey(), members...)
+	if err != nil || len(teams) == 0 {
+		return err
+	}
+
+	scores, found, err := b.ZMScore(ctx, tl.Individual.Name, members...)
+	if err != nil {
+		return err
+	}
+
+	changed := make(map[string]bool)
+	for i, member := range members {
+		team, ok := teams[member]
+		if !ok {
+			continue
+		}
+		if found[i] {
+			err = b.ZAdd(ctx, tl.Team(team).Name, Entry{Member: member, Score: scores[i]})
+		} else {
+			_, err = b.ZRem(ctx, tl.Team(team).Name, member)
+		}
+		if err != nil {
+			return err
+		}
+		changed[team] = true
+	}
+
+	for team := range changed {
+		if err := tl.score(ctx, team); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// score : set the score of team from the scores of its members.
+func (tl *TeamLeaderboard) score(ctx context.Context, team string) error {
+	stop := tl.topN - 1
+	if tl.topN < 1 {
+		stop = -1
+	}
+	_, err := tl.Individual.backend.ZSumStore(ctx, tl.Teams.Name, team, tl.Team(team).Name, 0, stop, tl.Teams.order == Descending)
+	return err
+}
+
+// Resync : Rebuild every team from the individual leaderboard, after writes that bypassed Individual.
+func (tl *TeamLeaderboard) Resync(ctx context.Context) error {
+	b := tl.Individual.backend
+	teamOf, err := b.HGetAll(ctx, tl.teamOfKey())
+	if err != nil {
+		return err
+	}
+	if err := b.Del(ctx, tl.Teams.Name); err != nil {
+		return err
+	}
+
+	members := make([]string, 0, len(teamOf))
+	for member, team := range teamOf {
+		members = append(members, member)
+		if err := b.Del(ctx, tl.Team(team).Name); err != nil {
+			return err
+		}
+	}
+	return tl.sync(ctx, members)
+}
+
+// Delete : Delete the individual leaderboard, the teams and the team memberships.
+func (tl *TeamLeaderboard) Delete(ctx context.Context) error {
+	b := tl.Individual.backend
+	teamOf, err := b.HGetAll(ctx, tl.teamOfKey())
+	if err != nil {
+		return err
+	}
+	for _, team := range teamOf {
+		if err := tl.Team(team).Delete(ctx); err != nil {
+			return err
+		}
+	}
+	for _, lb := range []*Leaderboard{tl.Individual, tl.Teams} {
+		if err := lb.Delete(ctx); err != nil {
+			return err
+		}
+	}
+	return b.Del(ctx, tl.teamOfKey())
+}
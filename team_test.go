@@ -0,0 +1,75 @@
+package rank
+
+import (
+	"context"
+	"testing"
+)
+
+func testTeamLeaderboard(t *testing.T, b Backend) {
+	ctx := context.Background()
+	tl := NewTeamLeaderboardWithBackend(b, lbName, 2)
+	defer tl.Delete(ctx)
+
+	tl.JoinTeam(ctx, "member_1", "red")
+	tl.JoinTeam(ctx, "member_2", "red")
+	tl.JoinTeam(ctx, "member_3", "red")
+	tl.JoinTeam(ctx, "member_4", "blue")
+
+	lb := tl.Individual
+	lb.RankMember(ctx, "member_1", 10)
+	lb.RankMember(ctx, "member_2", 20)
+	lb.RankMember(ctx, "member_3", 30)
+	lb.RankMember(ctx, "member_4", 45)
+
+	// red scores its best two members.
+	if score, _ := tl.Teams.ScoreFor(ctx, "red"); score != 50 {
+		t.Error("TeamLeaderboard ScoreFor Err!", score)
+	}
+	if rank, _ := tl.Teams.RankFor(ctx, "blue"); rank != 2 {
+		t.Error("TeamLeaderboard RankFor Err!", rank)
+	}
+	if rank, _ := tl.Team("red").RankFor(ctx, "member_2"); rank != 2 {
+		t.Error("TeamLeaderboard Team RankFor Err!", rank)
+	}
+
+	lb.ChangeScoreFor(ctx, "member_1", 25)
+	if score, _ := tl.Teams.ScoreFor(ctx, "red"); score != 65 {
+		t.Error("TeamLeaderboard ChangeScoreFor Err!", score)
+	}
+
+	lb.RemoveMember(ctx, "member_3")
+	if score, _ := tl.Teams.ScoreFor(ctx, "red"); score != 55 {
+		t.Error("TeamLeaderboard RemoveMember Err!", score)
+	}
+	if members, _ := tl.Teams.Members(ctx, 1, 0); len(members) != 2 || members[0].Member != "red" {
+		t.Error("TeamLeaderboard Members Err!", members)
+	}
+
+	tl.JoinTeam(ctx, "member_2", "blue")
+	if score, _ := tl.Teams.ScoreFor(ctx, "blue"); score != 65 {
+		t.Error("TeamLeaderboard JoinTeam Err!", score)
+	}
+	if team, _ := tl.TeamOf(ctx, "member_2"); team != "blue" {
+		t.Error("TeamLeaderboard TeamOf Err!", team)
+	}
+
+	tl.LeaveTeam(ctx, "member_1")
+	if ok, _ := tl.Teams.CheckMember(ctx, "red"); ok {
+		t.Error("TeamLeaderboard LeaveTeam Err!")
+	}
+
+	// writes that bypass Individual are picked up by Resync.
+	NewLeaderboardWithBackend(b, lbName).RankMember(ctx, "member_4", 100)
+	if err := tl.Resync(ctx); err != nil {
+		t.Error("TeamLeaderboard Resync Err!", err)
+	}
+	if score, _ := tl.Teams.ScoreFor(ctx, "blue"); score != 120 {
+		t.Error("TeamLeaderboard Resync Err!", score)
+	}
+}
+
+func TestTeamLeaderboard(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	testTeamLeaderboard(t, backend)
+	testTeamLeaderboard(t, NewMemoryBackend())
+}
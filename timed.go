@@ -0,0 +1,236 @@
+package rank
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// Period : time window of a board kept by a TimedLeaderboard.
+type Period int
+
+const (
+	// AllTime : never resets. stored under the name of the TimedLeaderboard itself.
+	AllTime Period = iota
+	// Daily : resets at midnight.
+	Daily
+	// Weekly : resets at midnight of the week start day.
+	Weekly
+	// Monthly : resets at midnight of the first day of the month.
+	Monthly
+)
+
+func (p Period) String() string {
+	switch p {
+	case Daily:
+		return "daily"
+	case Weekly:
+		return "weekly"
+	case Monthly:
+		return "monthly"
+	}
+	return "alltime"
+}
+
+// TimedLeaderboard : boards of several periods fed by one submission.
+// the board of a period is stored under "name:period:start", e.g. "scores:weekly:2024-03-04".
+type TimedLeaderboard struct {
+	Name      string
+	backend   Backend
+	periods   []Period
+	location  *time.Location
+	weekStart time.Weekday
+	retention int
+	options   []Option
+	now       func() time.Time
+
+	mu       sync.Mutex
+	expiring map[string]time.Time // boards given their expiry, by name. the value is when they expire.
+}
+
+// TimedOption : option for NewTimedLeaderboard.
+type TimedOption func(*TimedLeaderboard)
+
+// WithLocation : time zone of the period boundaries. default UTC.
+func WithLocation(location *time.Location) TimedOption {
+	return func(tl *TimedLeaderboard) {
+		tl.location = location
+	}
+}
+
+// WithWeekStart : first day of a Weekly period. default time.Monday.
+func WithWeekStart(day time.Weekday) TimedOption {
+	return func(tl *TimedLeaderboard) {
+		tl.weekStart = day
+	}
+}
+
+// WithRetention : number of past periods kept before a board expires. default 1, the previous period.
+func WithRetention(periods int) TimedOption {
+	return func(tl *TimedLeaderboard) {
+		tl.retention = periods
+	}
+}
+
+// WithBoardOptions : options applied to the board of every period.
+func WithBoardOptions(options ...Option) TimedOption {
+	return func(tl *TimedLeaderboard) {
+		tl.options = append(tl.options, options...)
+	}
+}
+
+// NewTimedLeaderboard : create a timed leaderboard named lbName backed by a redis pool.
+func NewTimedLeaderboard(pool *redis.Pool, lbName string, periods []Period, options ...TimedOption) *TimedLeaderboard {
+	return NewTimedLeaderboardWithBackend(NewRedisBackend(pool), lbName, periods, options...)
+}
+
+// NewTimedLeaderboardWithBackend : create a timed leaderboard named lbName stored in backend.
+func NewTimedLeaderboardWithBackend(backend Backend, lbName string, periods []Period, options ...TimedOption) *TimedLeaderboard {
+	tl := &TimedLeaderboard{
+		Name:      lbName,
+		backend:   backend,
+		periods:   periods,
+		location:  time.UTC,
+		weekStart: time.Monday,
+		retention: 1,
+		now:       time.Now,
+		expiring:  map[string]time.Time{},
+	}
+	for _, option := range options {
+		option(tl)
+	}
+	return tl
+}
+
+// Bounds : start and end of the period containing t. AllTime has zero bounds.
+func (tl *TimedLeaderboard) Bounds(period Period, t time.Time) (time.Time, time.Time) {
+	t = t.In(tl.location)
+	year, month, day := t.Date()
+	midnight := time.Date(year, month, day, 0, 0, 0, 0, tl.location)
+
+	switch period {
+	case Daily:
+		return midnight, midnight.AddDate(0, 0, 1)
+	case Weekly:
+		start := midnight.AddDate(0, 0, -((int(midnight.Weekday()) - int(tl.weekStart) + 7) % 7))
+		return start, start.AddDate(0, 0, 7)
+	case Monthly:
+		start := time.Date(year, month, 1, 0, 0, 0, 0, tl.location)
+		return start, start.AddDate(0, 1, 0)
+	}
+	return time.Time{}, time.Time{}
+}
+
+// At : board of the period containing t.
+func (tl *TimedLeaderboard) At(period Period, t time.Time) *Leaderboard {
+	name := tl.Name
+	if period != AllTime {
+		start, _ := tl.Bounds(period, t)
+		layout := "2006-01-02"
+		if period == Monthly {
+			layout = "2006-01"
+		}
+		name += ":" + period.String() + ":" + start.Format(layout)
+	}
+	return NewLeaderboardWithBackend(tl.backend, name, tl.options...)
+}
+
+// Current : board of the running period.
+func (tl *TimedLeaderboard) Current(period Period) *Leaderboard {
+	return tl.At(period, tl.now())
+}
+
+// Previous : board of the period before the running one.
+func (tl *TimedLeaderboard) Previous(period Period) *Leaderboard {
+	start, _ := tl.Bounds(period, tl.now())
+	return tl.At(period, start.Add(-time.Nanosecond))
+}
+
+// RankMember : Rank a member in the current board of every period.
+func (tl *TimedLeaderboard) RankMember(ctx context.Context, member string, score float64) error {
+	now := tl.now()
+	for _, period := range tl.periods {
+		lb := tl.At(period, now)
+		if err := lb.RankMember(ctx, member, score); err != nil {
+			return err
+		}
+		if err := tl.expire(ctx, lb, period, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RankMemberWithPolicy : Rank a member in the current board of every period applying policy.
+// e.g. PolicyMax keeps the best score of each period.
+func (tl *TimedLeaderboard) RankMemberWithPolicy(ctx context.Context, member string, score float64, policy UpdatePolicy) (map[Period]*UpdateResult, error) {
+	now := tl.now()
+	res := make(map[Period]*UpdateResult, len(tl.periods))
+	for _, period := range tl.periods {
+		lb := tl.At(period, now)
+		update, err := lb.RankMemberWithPolicy(ctx, member, score, policy)
+		if err != nil {
+			return nil, err
+		}
+		if err := tl.expire(ctx, lb, period, now); err != nil {
+			return nil, err
+		}
+		res[period] = update
+	}
+	return res, nil
+}
+
+// RemoveMember : Remove a member from the current board of every period.
+func (tl *TimedLeaderboard) RemoveMember(ctx context.Context, member string) error {
+	for _, period := range tl.periods {
+		lb := tl.Current(period)
+		if err := lb.RemoveMember(ctx, member); err != nil {
+			return err
+		}
+		// the board may be empty and gone now. the next submission creates it again without an expiry.
+		tl.mu.Lock()
+		delete(tl.expiring, lb.Name)
+		tl.mu.Unlock()
+	}
+	return nil
+}
+
+// expire : let the board of period at now expire once the retention periods after it are over.
+// the expiry is set by the first submission to a board only, later ones find it in tl.expiring.
+// a board deleted by other means than RemoveMember is created again without an expiry.
+func (tl *TimedLeaderboard) expire(ctx context.Context, lb *Leaderboard, period Period, now time.Time) error {
+	if period == AllTime {
+		return nil
+	}
+
+	tl.mu.Lock()
+	_, ok := tl.expiring[lb.Name]
+	tl.mu.Unlock()
+	if ok {
+		return nil
+	}
+
+	_, end := tl.Bounds(period, now)
+	for i := 0; i < tl.retention; i++ {
+		_, end = tl.Bounds(period, end)
+	}
+	if err := lb.ExpireAt(ctx, end); err != nil {
+		return err
+	}
+	if !end.After(now) {
+		// the board was deleted right away. a later submission creates it again.
+		return nil
+	}
+
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+	for name, at := range tl.expiring {
+		if !at.After(now) {
+			delete(tl.expiring, name)
+		}
+	}
+	tl.expiring[lb.Name] = end
+	return nil
+}
@@ -0,0 +1,146 @@
+package rank
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+func TestTimedLeaderboardBounds(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	seoul := time.FixedZone("KST", 9*60*60)
+	tl := NewTimedLeaderboardWithBackend(NewMemoryBackend(), lbName, nil, WithLocation(seoul), WithWeekStart(time.Sunday))
+
+	// 2024-03-09 23:30 UTC is Sunday 2024-03-10 08:30 in Seoul.
+	at := time.Date(2024, 3, 9, 23, 30, 0, 0, time.UTC)
+	expected := map[Period]string{
+		AllTime: lbName,
+		Daily:   lbName + ":daily:2024-03-10",
+		Weekly:  lbName + ":weekly:2024-03-10",
+		Monthly: lbName + ":monthly:2024-03",
+	}
+	for period, name := range expected {
+		if lb := tl.At(period, at); lb.Name != name {
+			t.Error("TimedLeaderboard At Err!", period, lb.Name, name)
+		}
+	}
+
+	start, end := tl.Bounds(Weekly, at)
+	if !start.Equal(time.Date(2024, 3, 10, 0, 0, 0, 0, seoul)) || !end.Equal(time.Date(2024, 3, 17, 0, 0, 0, 0, seoul)) {
+		t.Error("TimedLeaderboard Bounds Err!", start, end)
+	}
+	if lb := NewTimedLeaderboardWithBackend(NewMemoryBackend(), lbName, nil).At(Weekly, at); lb.Name != lbName+":weekly:2024-03-04" {
+		t.Error("TimedLeaderboard At Err!", lb.Name)
+	}
+}
+
+func testTimedLeaderboard(t *testing.T, b Backend) {
+	ctx := context.Background()
+	tl := NewTimedLeaderboardWithBackend(b, lbName, []Period{AllTime, Daily, Weekly, Monthly})
+	defer func() {
+		for _, period := range tl.periods {
+			tl.Current(period).Delete(ctx)
+			tl.Previous(period).Delete(ctx)
+		}
+	}()
+
+	now := time.Now()
+	yesterday, _ := tl.Bounds(Daily, now)
+	yesterday = yesterday.Add(-time.Hour)
+
+	tl.now = func() time.Time { return yesterday }
+	tl.RankMember(ctx, "member_1", 50)
+	tl.RankMember(ctx, "member_2", 40)
+
+	tl.now = func() time.Time { return now }
+	tl.RankMember(ctx, "member_2", 10)
+	tl.RankMember(ctx, "member_3", 30)
+
+	if members, _ := tl.Current(Daily).Members(ctx, 1, 0); len(members) != 2 || members[0].Member != "member_3" {
+		t.Error("TimedLeaderboard Current Err!", members)
+	}
+	if members, _ := tl.Previous(Daily).Members(ctx, 1, 0); len(members) != 2 || members[0].Member != "member_1" {
+		t.Error("TimedLeaderboard Previous Err!", members)
+	}
+	if rank, _ := tl.Current(AllTime).RankFor(ctx, "member_2"); rank != 3 {
+		t.Error("TimedLeaderboard RankFor Err!", rank)
+	}
+	if members, _ := tl.Current(AllTime).AroundMe(ctx, "member_3", 3); len(members) != 3 {
+		t.Error("TimedLeaderboard AroundMe Err!", members)
+	}
+
+	// boards older than the retention expire.
+	tl.now = func() time.Time { return now.AddDate(0, 0, -3) }
+	tl.RankMember(ctx, "member_4", 100)
+	if count, _ := tl.At(Daily, now.AddDate(0, 0, -3)).TotalMembers(ctx); count != 0 {
+		t.Error("TimedLeaderboard expire Err!", count)
+	}
+	if rank, _ := tl.Current(AllTime).RankFor(ctx, "member_4"); rank != 1 {
+		t.Error("TimedLeaderboard RankFor Err!", rank)
+	}
+
+	tl.now = func() time.Time { return now }
+	results, err := tl.RankMemberWithPolicy(ctx, "member_3", 20, PolicyMax)
+	if err != nil || len(results) != 4 || results[Daily].Changed || results[Daily].GetScore() != 30 {
+		t.Error("TimedLeaderboard RankMemberWithPolicy Err!", results, err)
+	}
+}
+
+func TestTimedLeaderboard(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	testTimedLeaderboard(t, backend)
+	testTimedLeaderboard(t, NewMemoryBackend())
+}
+
+func TestTimedLeaderboardExpire(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	ctx := context.Background()
+	pool, roundTrips := roundTripPool()
+	tl := NewTimedLeaderboard(pool, lbName, []Period{Daily})
+	defer tl.Current(Daily).Delete(ctx)
+	c := pool.Get()
+	defer c.Close()
+
+	tl.RankMember(ctx, "member_1", 10)
+	if ttl, _ := redis.Int(c.Do("TTL", tl.Current(Daily).Name)); ttl <= 0 {
+		t.Error("TimedLeaderboard expire Err!", ttl)
+	}
+
+	// later submissions do not set the expiry again.
+	atomic.StoreInt64(roundTrips, 0)
+	tl.RankMember(ctx, "member_2", 20)
+	if count := atomic.LoadInt64(roundTrips); count != 1 {
+		t.Error("TimedLeaderboard expire Err!", count)
+	}
+
+	// a board emptied by RemoveMember gets its expiry again.
+	tl.RemoveMember(ctx, "member_1")
+	tl.RemoveMember(ctx, "member_2")
+	tl.RankMember(ctx, "member_3", 30)
+	if ttl, _ := redis.Int(c.Do("TTL", tl.Current(Daily).Name)); ttl <= 0 {
+		t.Error("TimedLeaderboard expire Err!", ttl)
+	}
+}
+
+func BenchmarkTimedLeaderboardRankMember(b *testing.B) {
+	ctx := context.Background()
+	pool, roundTrips := roundTripPool()
+	tl := NewTimedLeaderboard(pool, lbName, []Period{AllTime, Daily, Weekly, Monthly})
+	defer func() {
+		for _, period := range tl.periods {
+			tl.Current(period).Delete(ctx)
+		}
+	}()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := tl.RankMember(ctx, "member_"+strconv.Itoa(i%1000), float64(i)); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(atomic.LoadInt64(roundTrips))/float64(b.N), "roundtrips/op")
+}
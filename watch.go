@@ -0,0 +1,168 @@
+package rank
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// Crossing : a member crossing a watched threshold.
+type Crossing struct {
+	// Watch : crossed threshold, e.g. "top:100" or "score:5000".
+	Watch  string
+	Member string
+	// Entered : true when the member reached the threshold, false when it fell back behind it.
+	Entered bool
+	// Score, Rank : standing of the member after the crossing. 0 when the member was removed.
+	Score float64
+	Rank  int
+}
+
+func (c *Crossing) String() string {
+	return fmt.Sprintf("watch:%s member:%s entered:%t score:%v rank:%d", c.Watch, c.Member, c.Entered, c.Score, c.Rank)
+}
+
+// WatchFunc : called for each crossing after the write that caused it.
+// an error is returned by the write, after the write.
+type WatchFunc func(ctx context.Context, crossing *Crossing) error
+
+// watch : threshold watched by a leaderboard. a rank watch when top > 0, else a score watch.
+type watch struct {
+	name  string
+	top   int
+	score float64
+	fn    WatchFunc
+}
+
+// WithTopWatch : call fn when a member enters or leaves the top n ranks, including the members
+// pushed out of the top n by the update of another member.
+// the top n is remembered in "name:top:n". the first write after the watch is added to a filled
+// leaderboard reports the whole top n as entered. fn may be nil to only publish events.
+func WithTopWatch(n int, fn WatchFunc) Option {
+	return func(lb *Leaderboard) {
+		lb.watches = append(lb.watches, &watch{name: "top:" + strconv.Itoa(n), top: n, fn: fn})
+	}
+}
+
+// WithScoreWatch : call fn when the score of a member reaches threshold, or falls back behind it.
+// fn may be nil to only publish events.
+func WithScoreWatch(threshold float64, fn WatchFunc) Option {
+	return func(lb *Leaderboard) {
+		lb.watches = append(lb.watches, &watch{name: "score:" + strconv.FormatFloat(threshold, 'f', -1, 64), score: threshold, fn: fn})
+	}
+}
+
+// watchKey : set of the members in the top of a rank watch.
+func (lb *Leaderboard) watchKey(w *watch) string {
+	return lb.Name + ":" + w.name
+}
+
+// watch : report the crossings caused by a write of members, to the watch functions and as events.
+func (lb *Leaderboard) watch(ctx context.Context, before snapshot, after snapshot, members []string) error {
+	for _, w := range lb.watches {
+		var crossings []*Crossing
+		var err error
+		if w.top > 0 {
+			crossings, err = lb.topCrossings(ctx, w, before, after, members)
+		} else {
+			crossings = lb.scoreCrossings(w, before, after, members)
+		}
+		if err != nil {
+			return err
+		}
+		if len(crossings) == 0 {
+			continue
+		}
+
+		events := make([]*Event, 0, len(crossings))
+		for _, crossing := range crossings {
+			event := &Event{Type: EventLeft, Member: crossing.Member, NewScore: crossing.Score, NewRank: crossing.Rank, Watch: w.name}
+			if crossing.Entered {
+				event.Type = EventEntered
+			}
+			if old, ok := before[crossing.Member]; ok {
+				event.OldScore, event.OldRank = old.score, old.rank
+			}
+			events = append(events, event)
+		}
+		if err := lb.send(ctx, events); err != nil {
+			return err
+		}
+
+		if w.fn == nil {
+			continue
+		}
+		for _, crossing := range crossings {
+			if err := w.fn(ctx, crossing); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// scoreCrossings : members whose new score is on the other side of the threshold.
+func (lb *Leaderboard) scoreCrossings(w *watch, before snapshot, after snapshot, members []string) []*Crossing {
+	reached := func(s snapshot, member string) bool {
+		standing, ok := s[member]
+		return ok && !lb.better(w.score, standing.score)
+	}
+
+	var crossings []*Crossing
+	seen := make(map[string]bool)
+	for _, member := range members {
+		if seen[member] {
+			continue
+		}
+		seen[member] = true
+
+		entered := reached(after, member)
+		if entered == reached(before, member) {
+			continue
+		}
+		crossing := &Crossing{Watch: w.name, Member: member, Entered: entered}
+		if standing, ok := after[member]; ok {
+			crossing.Score, crossing.Rank = standing.score, standing.rank
+		}
+		crossings = append(crossings, crossing)
+	}
+	return crossings
+}
+
+// topCrossings : members that entered or left the top since the last write that changed it.
+// the top is read and compared with the remembered one in one backend step, so concurrent writers
+// report each crossing once.
+func (lb *Leaderboard) topCrossings(ctx context.Context, w *watch, before snapshot, after snapshot, members []string) ([]*Crossing, error) {
+	// the top only changes when a written member was or is in it.
+	changed := false
+	for _, member := range members {
+		for _, s := range []snapshot{before, after} {
+			if standing, ok := s[member]; ok && standing.rank <= w.top {
+				changed = true
+			}
+		}
+	}
+	if !changed {
+		return nil, nil
+	}
+
+	entered, left, err := lb.backend.ZTopStore(ctx, lb.watchKey(w), lb.Name, w.top, lb.order != Ascending, lb.rankingMode, lb.timestampKey())
+	if err != nil || len(entered)+len(left) == 0 {
+		return nil, err
+	}
+	changes := append(entered, left...)
+	current, err := lb.snapshot(ctx, changes...)
+	if err != nil {
+		return nil, err
+	}
+
+	crossings := make([]*Crossing, 0, len(changes))
+	for i, member := range changes {
+		crossing := &Crossing{Watch: w.name, Member: member, Entered: i < len(entered)}
+		if standing, ok := current[member]; ok {
+			crossing.Score, crossing.Rank = standing.score, standing.rank
+		}
+		crossings = append(crossings, crossing)
+	}
+	return crossings, nil
+}
@@ -0,0 +1,187 @@
+package rank
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func testWatches(t *testing.T, b Backend) {
+	ctx := context.Background()
+	var crossings []*Crossing
+	record := func(ctx context.Context, crossing *Crossing) error {
+		crossings = append(crossings, crossing)
+		return nil
+	}
+	expect := func(name string, expected ...string) {
+		t.Helper()
+		got := make([]string, 0, len(crossings))
+		for _, crossing := range crossings {
+			sign := "-"
+			if crossing.Entered {
+				sign = "+"
+			}
+			got = append(got, sign+crossing.Member)
+		}
+		if len(got) != len(expected) {
+			t.Error("Leaderboard "+name+" Err!", crossings)
+		} else {
+			for i := range got {
+				if got[i] != expected[i] {
+					t.Error("Leaderboard "+name+" Err!", crossings)
+					break
+				}
+			}
+		}
+		crossings = nil
+	}
+
+	lb := NewLeaderboardWithBackend(b, lbName, WithTopWatch(2, record), WithScoreWatch(25, record), WithEventStream(0))
+	defer lb.Delete(ctx)
+	defer b.Del(ctx, lb.eventsKey())
+
+	lb.RankMember(ctx, "member_1", 10)
+	lb.RankMember(ctx, "member_2", 20)
+	expect("WithTopWatch", "+member_1", "+member_2")
+
+	// member_1 is pushed out of the top 2 by member_3.
+	lb.RankMember(ctx, "member_3", 30)
+	if len(crossings) != 3 || crossings[1].Member != "member_1" || crossings[1].Rank != 3 || crossings[1].Score != 10 {
+		t.Error("Leaderboard WithTopWatch Err!", crossings)
+	}
+	expect("WithTopWatch", "+member_3", "-member_1", "+member_3")
+
+	// moves outside the top leave it as it is.
+	lb.RankMember(ctx, "member_4", 5)
+	lb.ChangeScoreFor(ctx, "member_4", 1)
+	expect("WithTopWatch")
+
+	lb.ChangeScoreFor(ctx, "member_2", 10)
+	expect("WithScoreWatch", "+member_2")
+
+	lb.RemoveMember(ctx, "member_3")
+	expect("RemoveMember", "+member_1", "-member_3", "-member_3")
+
+	lb.RemoveMembersInScoreRange(ctx, 25, 40)
+	expect("RemoveMembersInScoreRange", "+member_4", "-member_2", "-member_2")
+
+	events, err := lb.ReadEvents(ctx, "0", 0, 0)
+	if err != nil {
+		t.Fatal("Leaderboard ReadEvents Err!", err)
+	}
+	watched := 0
+	for _, e := range events {
+		if e.Type == EventEntered || e.Type == EventLeft {
+			watched++
+		}
+		if e.Type == EventLeft && e.Member == "member_1" && (e.Watch != "top:2" || e.NewRank != 3) {
+			t.Error("Leaderboard Watch Event Err!", e)
+		}
+	}
+	if watched != 12 {
+		t.Error("Leaderboard Watch Events Err!", watched)
+	}
+
+	// ties at the boundary share the top in standard ranking.
+	tied := NewLeaderboardWithBackend(b, lbName+"_tied", WithRankingMode(RankStandard), WithTopWatch(2, record))
+	defer tied.Delete(ctx)
+	tied.RankMembers(ctx, []*RankScore{NewRankScore("member_1", 30), NewRankScore("member_2", 20), NewRankScore("member_3", 20)})
+	expect("WithTopWatch RankStandard", "+member_1", "+member_3", "+member_2")
+}
+
+func TestWatches(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	testWatches(t, backend)
+	testWatches(t, NewMemoryBackend())
+}
+
+func testWatchesModes(t *testing.T, b Backend) {
+	ctx := context.Background()
+	expected := map[RankingMode][]string{
+		RankStandard:      {"member_a", "member_b", "member_c"},
+		RankDense:         {"member_a", "member_b", "member_c", "member_d"},
+		RankOrdinal:       {"member_b", "member_c"},
+		RankEarliestFirst: {"member_a", "member_b"},
+	}
+	for mode, members := range expected {
+		top := make(map[string]bool)
+		record := func(ctx context.Context, crossing *Crossing) error {
+			top[crossing.Member] = crossing.Entered
+			return nil
+		}
+
+		lb := NewLeaderboardWithBackend(b, lbName, WithRankingMode(mode), WithTopWatch(2, record))
+		now := time.UnixMilli(1700000000000)
+		lb.now = func() time.Time {
+			now = now.Add(time.Second)
+			return now
+		}
+		lb.RankMember(ctx, "member_a", 10)
+		lb.RankMember(ctx, "member_b", 10)
+		lb.RankMember(ctx, "member_c", 10)
+		lb.RankMember(ctx, "member_d", 5)
+		lb.Delete(ctx)
+
+		for member, in := range top {
+			if !in {
+				delete(top, member)
+			}
+		}
+		if len(top) != len(members) {
+			t.Error("Leaderboard WithTopWatch Mode Err!", mode, top)
+		}
+		for _, member := range members {
+			if !top[member] {
+				t.Error("Leaderboard WithTopWatch Mode Err!", mode, top)
+			}
+		}
+	}
+}
+
+func TestWatchesModes(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	testWatchesModes(t, backend)
+	testWatchesModes(t, NewMemoryBackend())
+}
+
+func testWatchesConcurrent(t *testing.T, b Backend) {
+	ctx := context.Background()
+	var mu sync.Mutex
+	entered := make(map[string]int)
+	record := func(ctx context.Context, crossing *Crossing) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if crossing.Entered {
+			entered[crossing.Member]++
+		}
+		return nil
+	}
+
+	lb := NewLeaderboardWithBackend(b, lbName, WithTopWatch(3, record))
+	defer lb.Delete(ctx)
+	lb.RankMember(ctx, "member_1", 10)
+
+	// writers of other processes see the same member enter the top. only one reports it.
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			writer := NewLeaderboardWithBackend(b, lbName, WithTopWatch(3, record))
+			writer.RankMember(ctx, fmt.Sprintf("member_%d", 2+i%2), 20)
+		}(i)
+	}
+	wg.Wait()
+
+	if entered["member_1"] != 1 || entered["member_2"] != 1 || entered["member_3"] != 1 {
+		t.Error("Leaderboard WithTopWatch Concurrent Err!", entered)
+	}
+}
+
+func TestWatchesConcurrent(t *testing.T) {
+	///////////////////////////////////////////////////////////////////////////
+	testWatchesConcurrent(t, backend)
+	testWatchesConcurrent(t, NewMemoryBackend())
+}